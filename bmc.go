@@ -1,6 +1,10 @@
 package ipmigo
 
-import "fmt"
+import (
+	"fmt"
+	"net"
+	"time"
+)
 
 // ** Self Test
 type SelfTestStatus uint8
@@ -133,3 +137,168 @@ func (c *SetColdResetCommand) Unmarshal(buf []byte) ([]byte, error) {
 
 	return nil, nil
 }
+
+// ** Warm Reset
+
+// SetWarmResetCommand Warm Reset Command (section 20.3)
+type SetWarmResetCommand struct {
+	// Request Data
+
+	// Response Data
+
+}
+
+func (c *SetWarmResetCommand) Name() string { return "Set Warm Reset" }
+func (c *SetWarmResetCommand) Code() uint8  { return 0x03 }
+
+func (c *SetWarmResetCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnAppReq, 0)
+}
+
+func (c *SetWarmResetCommand) String() string           { return cmdToJSON(c) }
+func (c *SetWarmResetCommand) Marshal() ([]byte, error) { return []byte{}, nil }
+
+func (c *SetWarmResetCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 0); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ** Reset and wait
+
+// BMCResetStage Stage of BMCColdReset/BMCWarmReset
+type BMCResetStage uint8
+
+const (
+	BMCResetStageSent         BMCResetStage = iota // Reset command has been sent
+	BMCResetStageWaitPing                          // Waiting for ASF Pong
+	BMCResetStageWaitDeviceID                      // Waiting for session and Get Device ID
+	BMCResetStageWaitSelfTest                      // Waiting for passed self test
+	BMCResetStageReady                             // BMC is back and session is reopened
+)
+
+func (s BMCResetStage) String() string {
+	switch s {
+	case BMCResetStageSent:
+		return "reset sent"
+	case BMCResetStageWaitPing:
+		return "waiting for ping"
+	case BMCResetStageWaitDeviceID:
+		return "waiting for device id"
+	case BMCResetStageWaitSelfTest:
+		return "waiting for self test"
+	case BMCResetStageReady:
+		return "ready"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(s))
+	}
+}
+
+// BMCResetOptions Options for BMCColdReset and BMCWarmReset
+type BMCResetOptions struct {
+	Timeout      time.Duration // Total timeout (The default is 5min)
+	InitialDelay time.Duration // Wait before the first poll, BMC can still respond right after reset (The default is 10sec)
+	PollInterval time.Duration // Interval between polls (The default is 5sec)
+
+	// Progress is called when the reset is sent, after each failed poll with the stage and its error,
+	// and when the BMC is ready
+	Progress func(stage BMCResetStage, elapsed time.Duration, err error)
+}
+
+func (o *BMCResetOptions) setDefault() {
+	if o.Timeout == 0 {
+		o.Timeout = 5 * time.Minute
+	}
+	if o.InitialDelay == 0 {
+		o.InitialDelay = 10 * time.Second
+	}
+	if o.PollInterval == 0 {
+		o.PollInterval = 5 * time.Second
+	}
+}
+
+// BMCColdReset Sends Cold Reset, waits until the BMC is back and reopens the session.
+func BMCColdReset(c *Client, opts *BMCResetOptions) error {
+	return bmcResetWait(c, &SetColdResetCommand{}, opts)
+}
+
+// BMCWarmReset Sends Warm Reset, waits until the BMC is back and reopens the session.
+func BMCWarmReset(c *Client, opts *BMCResetOptions) error {
+	return bmcResetWait(c, &SetWarmResetCommand{}, opts)
+}
+
+func bmcResetWait(c *Client, cmd Command, opts *BMCResetOptions) error {
+	var o BMCResetOptions
+	if opts != nil {
+		o = *opts
+	}
+	o.setDefault()
+
+	start := time.Now()
+	progress := func(stage BMCResetStage, err error) {
+		if o.Progress != nil {
+			o.Progress(stage, time.Since(start), err)
+		}
+	}
+
+	if err := c.Execute(cmd); err != nil {
+		// BMC may reset before sending the response
+		if e, ok := err.(net.Error); !ok || !e.Timeout() {
+			return err
+		}
+	}
+	progress(BMCResetStageSent, nil)
+
+	// The session has been lost with the reset
+	_ = c.session.Abandon()
+	time.Sleep(o.InitialDelay)
+
+	deadline := start.Add(o.Timeout)
+	for {
+		stage, err := bmcResetPoll(c)
+		if err == nil {
+			progress(BMCResetStageReady, nil)
+			return nil
+		}
+		_ = c.session.Abandon()
+		progress(stage, err)
+
+		if time.Now().Add(o.PollInterval).After(deadline) {
+			return &MessageError{
+				Cause:   err,
+				Message: fmt.Sprintf("BMC is not ready after reset in %s (%s)", o.Timeout, stage),
+			}
+		}
+		time.Sleep(o.PollInterval)
+	}
+}
+
+func bmcResetPoll(c *Client) (BMCResetStage, error) {
+	if err := c.Ping(); err != nil {
+		return BMCResetStageWaitPing, err
+	}
+
+	gdi := &GetDeviceIDCommand{}
+	if err := c.Execute(gdi); err != nil {
+		return BMCResetStageWaitDeviceID, err
+	}
+	if !gdi.DeviceAvailable {
+		return BMCResetStageWaitDeviceID, &MessageError{Message: "Device firmware, SDR Repository update or self-initialization in progress"}
+	}
+
+	gst := &GetSelfTestResultsCommand{}
+	if err := c.Execute(gst); err != nil {
+		return BMCResetStageWaitSelfTest, err
+	}
+	// Self test can not be evaluated if it is not implemented
+	if s := SelfTestStatus(gst.Status); s != SelfTestStatusAllPassed && s != SelfTestStatusNotImplemented {
+		return BMCResetStageWaitSelfTest, &MessageError{
+			Message: fmt.Sprintf("Self test failed : %s", s),
+			Detail:  gst.GetTestResultsAsString(),
+		}
+	}
+
+	return BMCResetStageReady, nil
+}
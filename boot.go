@@ -0,0 +1,237 @@
+package ipmigo
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	bootFlagsSize = 5
+)
+
+// BootDevice Boot device selector of the boot flags (Table 28-14, parameter 5)
+type BootDevice uint8
+
+const (
+	BootDeviceNone         BootDevice = 0x00 // No override
+	BootDevicePXE          BootDevice = 0x01
+	BootDeviceDisk         BootDevice = 0x02
+	BootDeviceDiskSafeMode BootDevice = 0x03
+	BootDeviceDiagnostic   BootDevice = 0x04
+	BootDeviceCDROM        BootDevice = 0x05
+	BootDeviceBIOSSetup    BootDevice = 0x06
+	BootDeviceRemoteFloppy BootDevice = 0x07 // Remotely connected floppy / primary removable media
+	BootDeviceRemoteCDROM  BootDevice = 0x08
+	BootDeviceRemoteMedia  BootDevice = 0x09 // Primary remote media
+	BootDeviceRemoteDisk   BootDevice = 0x0b
+	BootDeviceFloppy       BootDevice = 0x0f // Floppy / primary removable media
+)
+
+func (d BootDevice) String() string {
+	switch d {
+	case BootDeviceNone:
+		return "No override"
+	case BootDevicePXE:
+		return "Force PXE"
+	case BootDeviceDisk:
+		return "Force Boot from default Hard-Drive"
+	case BootDeviceDiskSafeMode:
+		return "Force Boot from default Hard-Drive, request Safe-Mode"
+	case BootDeviceDiagnostic:
+		return "Force Boot from Diagnostic Partition"
+	case BootDeviceCDROM:
+		return "Force Boot from CD/DVD"
+	case BootDeviceBIOSSetup:
+		return "Force Boot into BIOS Setup"
+	case BootDeviceRemoteFloppy:
+		return "Force Boot from remotely connected Floppy/primary removable media"
+	case BootDeviceRemoteCDROM:
+		return "Force Boot from remotely connected CD/DVD"
+	case BootDeviceRemoteMedia:
+		return "Force Boot from primary remote media"
+	case BootDeviceRemoteDisk:
+		return "Force Boot from remotely connected Hard-Drive"
+	case BootDeviceFloppy:
+		return "Force Boot from Floppy/primary removable media"
+	default:
+		return fmt.Sprintf("Reserved(%d)", uint8(d))
+	}
+}
+
+// BootInitiator Boot initiator bits of the boot info acknowledge (Table 28-14, parameter 4)
+type BootInitiator uint8
+
+const (
+	BootInitiatorBIOS             BootInitiator = 0x01 // BIOS/POST
+	BootInitiatorOSLoader         BootInitiator = 0x02
+	BootInitiatorServicePartition BootInitiator = 0x04 // OS / service partition
+	BootInitiatorSMS              BootInitiator = 0x08
+	BootInitiatorOEM              BootInitiator = 0x10
+)
+
+// BootFlags Boot flags (Table 28-14, parameter 5)
+type BootFlags struct {
+	Valid      bool
+	Persistent bool // `false`: applies to the next boot only
+	EFI        bool // `false`: PC compatible (legacy) boot
+
+	CMOSClear       bool
+	LockKeyboard    bool
+	Device          BootDevice
+	ScreenBlank     bool
+	LockResetButton bool
+
+	LockPowerButton    bool  // Lock out power off / sleep request via power button
+	FirmwareVerbosity  uint8 // (0: default, 1: quiet, 2: verbose)
+	ProgressEventTraps bool
+	PasswordBypass     bool
+	LockSleepButton    bool
+	ConsoleRedirection uint8 // (0: BIOS settings, 1: suppress, 2: request enabled)
+
+	BIOSSharedModeOverride bool
+	BIOSMuxControl         uint8
+
+	DeviceInstance uint8 // bits 4:0
+}
+
+func (f *BootFlags) Marshal() ([]byte, error) {
+	buf := make([]byte, bootFlagsSize)
+	if f.Valid {
+		buf[0] |= 0x80
+	}
+	if f.Persistent {
+		buf[0] |= 0x40
+	}
+	if f.EFI {
+		buf[0] |= 0x20
+	}
+
+	if f.CMOSClear {
+		buf[1] |= 0x80
+	}
+	if f.LockKeyboard {
+		buf[1] |= 0x40
+	}
+	buf[1] |= uint8(f.Device) & 0x0f << 2
+	if f.ScreenBlank {
+		buf[1] |= 0x02
+	}
+	if f.LockResetButton {
+		buf[1] |= 0x01
+	}
+
+	if f.LockPowerButton {
+		buf[2] |= 0x80
+	}
+	buf[2] |= f.FirmwareVerbosity & 0x03 << 5
+	if f.ProgressEventTraps {
+		buf[2] |= 0x10
+	}
+	if f.PasswordBypass {
+		buf[2] |= 0x08
+	}
+	if f.LockSleepButton {
+		buf[2] |= 0x04
+	}
+	buf[2] |= f.ConsoleRedirection & 0x03
+
+	if f.BIOSSharedModeOverride {
+		buf[3] |= 0x08
+	}
+	buf[3] |= f.BIOSMuxControl & 0x07
+
+	buf[4] = f.DeviceInstance & 0x1f
+	return buf, nil
+}
+
+func (f *BootFlags) Unmarshal(buf []byte) ([]byte, error) {
+	if l := len(buf); l < bootFlagsSize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid BootFlags size : %d/%d", l, bootFlagsSize),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	f.Valid = buf[0]&0x80 != 0
+	f.Persistent = buf[0]&0x40 != 0
+	f.EFI = buf[0]&0x20 != 0
+	f.CMOSClear = buf[1]&0x80 != 0
+	f.LockKeyboard = buf[1]&0x40 != 0
+	f.Device = BootDevice(buf[1] & 0x3c >> 2)
+	f.ScreenBlank = buf[1]&0x02 != 0
+	f.LockResetButton = buf[1]&0x01 != 0
+	f.LockPowerButton = buf[2]&0x80 != 0
+	f.FirmwareVerbosity = buf[2] & 0x60 >> 5
+	f.ProgressEventTraps = buf[2]&0x10 != 0
+	f.PasswordBypass = buf[2]&0x08 != 0
+	f.LockSleepButton = buf[2]&0x04 != 0
+	f.ConsoleRedirection = buf[2] & 0x03
+	f.BIOSSharedModeOverride = buf[3]&0x08 != 0
+	f.BIOSMuxControl = buf[3] & 0x07
+	f.DeviceInstance = buf[4] & 0x1f
+	return buf[bootFlagsSize:], nil
+}
+
+func (f *BootFlags) String() string { return toJSON(f) }
+
+// BootGetFlags Returns the current boot flags.
+func BootGetFlags(c *Client) (*BootFlags, error) {
+	gbo := &GetSystemBootOptionsCommand{ParameterSelector: BootOptionFlags}
+	if err := c.Execute(gbo); err != nil {
+		return nil, err
+	}
+
+	flags := &BootFlags{}
+	if _, err := flags.Unmarshal(gbo.Data); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// BootSetFlags Sets boot flags within Set In Progress and marks the boot info as not acknowledged by BIOS.
+func BootSetFlags(c *Client, flags *BootFlags) error {
+	data, err := flags.Marshal()
+	if err != nil {
+		return err
+	}
+
+	// Set Complete is only sent if Set In Progress is supported by BMC
+	inProgress := false
+	sip := &SetSystemBootOptionsCommand{
+		ParameterSelector: BootOptionSetInProgress,
+		Data:              []byte{0x01},
+	}
+	if err := c.Execute(sip); err == nil {
+		inProgress = true
+	} else if _, ok := err.(*CommandError); !ok {
+		return err
+	}
+
+	err = func() error {
+		ack := &SetSystemBootOptionsCommand{
+			ParameterSelector: BootOptionInfoAcknowledge,
+			Data:              []byte{uint8(BootInitiatorBIOS), uint8(BootInitiatorBIOS)},
+		}
+		if err := c.Execute(ack); err != nil {
+			return err
+		}
+		return c.Execute(&SetSystemBootOptionsCommand{ParameterSelector: BootOptionFlags, Data: data})
+	}()
+
+	if inProgress {
+		sip.Data = []byte{0x00}
+		if e := c.Execute(sip); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// BootSetDevice Sets the boot device for the next boot (or all boots if persistent).
+func BootSetDevice(c *Client, device BootDevice, persistent, efi bool) error {
+	return BootSetFlags(c, &BootFlags{
+		Valid:      true,
+		Persistent: persistent,
+		EFI:        efi,
+		Device:     device,
+	})
+}
@@ -0,0 +1,38 @@
+package ipmigo
+
+import (
+	"testing"
+)
+
+func TestBootFlagsData3(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags BootFlags
+		data3 byte
+	}{
+		{"LockPowerButton", BootFlags{LockPowerButton: true}, 0x80},
+		{"FirmwareVerbosity", BootFlags{FirmwareVerbosity: 2}, 0x40},
+		{"ProgressEventTraps", BootFlags{ProgressEventTraps: true}, 0x10},
+		{"PasswordBypass", BootFlags{PasswordBypass: true}, 0x08},
+		{"LockSleepButton", BootFlags{LockSleepButton: true}, 0x04},
+		{"ConsoleRedirection", BootFlags{ConsoleRedirection: 1}, 0x01},
+	}
+
+	for _, tt := range tests {
+		buf, err := tt.flags.Marshal()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if buf[2] != tt.data3 {
+			t.Errorf("%s: data3 = 0x%02x, want 0x%02x", tt.name, buf[2], tt.data3)
+		}
+
+		f := &BootFlags{}
+		if _, err := f.Unmarshal(buf); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if *f != tt.flags {
+			t.Errorf("%s: Unmarshal = %+v, want %+v", tt.name, *f, tt.flags)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package ipmigo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+)
+
+const (
+	ipmbResponseMinSize = 8
+)
+
+var bridgeRqSeq uint32
+
+// BridgedCommand Sends a command to a controller on an IPMB (or other channel) behind the BMC
+// with Send Message Command (Section 22.7), the response is tracked by the BMC.
+type BridgedCommand struct {
+	Channel       uint8 // Channel of the target controller (0: primary IPMB)
+	TargetAddress uint8 // Slave address of the target controller (8-bit form)
+	Command       Command
+
+	pending bool  // Response is not embedded in the Send Message response
+	rqSeq   uint8 // Sequence number of the encapsulated request
+}
+
+// NewBridgedCommand Returns a command routed to the controller, or cmd itself if the target is the BMC.
+func NewBridgedCommand(channel, targetAddress uint8, cmd Command) Command {
+	if channel == 0 && targetAddress&0xfe == bmcSlaveAddress {
+		return cmd
+	}
+	return &BridgedCommand{Channel: channel, TargetAddress: targetAddress & 0xfe, Command: cmd}
+}
+
+func (c *BridgedCommand) Name() string { return "Send Message (" + c.Command.Name() + ")" }
+func (c *BridgedCommand) Code() uint8  { return 0x34 }
+
+func (c *BridgedCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnAppReq, 0)
+}
+
+func (c *BridgedCommand) String() string { return cmdToJSON(c) }
+
+func (c *BridgedCommand) Marshal() ([]byte, error) {
+	data, err := c.Command.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	// Track request
+	buf := make([]byte, len(data)+8)
+	buf[0] = 0x40 | c.Channel&0x0f
+
+	// Encapsulated IPMB request (Section 22.7)
+	msg := buf[1:]
+	msg[0] = c.TargetAddress
+	msg[1] = byte(c.Command.NetFnRsLUN())
+	msg[2] = checksum(msg[0:2])
+	msg[3] = bmcSlaveAddress
+	c.rqSeq = uint8(atomic.AddUint32(&bridgeRqSeq, 1) & 0x3f)
+	msg[4] = c.rqSeq << 2
+	msg[5] = c.Command.Code()
+	copy(msg[6:], data)
+	msg[len(msg)-1] = checksum(msg[3 : len(msg)-1])
+
+	c.pending = false
+	return buf, nil
+}
+
+func (c *BridgedCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if len(buf) == 0 {
+		// Response will be sent by the BMC in a separate message
+		c.pending = true
+		return nil, nil
+	}
+
+	// Embedded IPMB response
+	if len(buf) < ipmbResponseMinSize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid bridged response size : %d", len(buf)),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	if csum := checksum(buf[0:2]); csum != buf[2] {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid bridged response 1st checksum(%d, %d)", csum, buf[2]),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	if l := len(buf); checksum(buf[3:l-1]) != buf[l-1] {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid bridged response 2nd checksum(%d, %d)", checksum(buf[3:l-1]), buf[l-1]),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	if seq, code := buf[4]>>2, buf[5]; seq != c.rqSeq || code != c.Command.Code() {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Mismatch bridged response rqSeq/cmd : %d/0x%02x - %d/0x%02x",
+				c.rqSeq, c.Command.Code(), seq, code),
+			Detail: hex.EncodeToString(buf),
+		}
+	}
+	if err := c.unmarshalResponse(CompletionCode(buf[6]), buf[7:len(buf)-1]); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (c *BridgedCommand) unmarshalResponse(cc CompletionCode, data []byte) error {
+	c.pending = false
+	if cc != CompletionOK {
+		return &CommandError{
+			CompletionCode: cc,
+			Command:        c.Command,
+		}
+	}
+	_, err := c.Command.Unmarshal(data)
+	return err
+}
@@ -0,0 +1,48 @@
+package ipmigo
+
+import (
+	"testing"
+)
+
+func TestBridgedCommandUnmarshal(t *testing.T) {
+	response := func(rqSeq, code uint8) []byte {
+		// rqAddr, netFn/rqLUN, chk1, rsAddr, rqSeq/rsLUN, cmd, cc, data (chassis status), chk2
+		buf := []byte{bmcSlaveAddress, byte(NetFnChassisRes) << 2, 0, 0x72, rqSeq << 2, code, 0x00, 0x01, 0x00, 0x00, 0}
+		buf[2] = checksum(buf[0:2])
+		buf[len(buf)-1] = checksum(buf[3 : len(buf)-1])
+		return buf
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *BridgedCommand, buf []byte) []byte
+		valid  bool
+	}{
+		{"valid", func(c *BridgedCommand, buf []byte) []byte { return buf }, true},
+		{"1st checksum", func(c *BridgedCommand, buf []byte) []byte { buf[2]++; return buf }, false},
+		{"2nd checksum", func(c *BridgedCommand, buf []byte) []byte { buf[len(buf)-1]++; return buf }, false},
+		{"rqSeq", func(c *BridgedCommand, buf []byte) []byte { return response(c.rqSeq+1, 0x01) }, false},
+		{"cmd", func(c *BridgedCommand, buf []byte) []byte { return response(c.rqSeq, 0x02) }, false},
+	}
+
+	for _, tt := range tests {
+		gcs := &GetChassisStatusCommand{}
+		c := NewBridgedCommand(0, 0x72, gcs).(*BridgedCommand)
+		if _, err := c.Marshal(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		_, err := c.Unmarshal(tt.modify(c, response(c.rqSeq, 0x01)))
+		if tt.valid {
+			if err != nil {
+				t.Errorf("%s: Unmarshal() = %v, want nil", tt.name, err)
+			} else if !gcs.PowerIsOn {
+				t.Errorf("%s: response is not decoded : %v", tt.name, gcs)
+			}
+			continue
+		}
+		if _, ok := err.(*MessageError); !ok {
+			t.Errorf("%s: Unmarshal() = %v, want *MessageError", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package ipmigo
+
+// Channel Channel configuration summary
+type Channel struct {
+	Number         uint8
+	MediumType     ChannelMediumType
+	ProtocolType   ChannelProtocolType
+	SessionSupport ChannelSessionSupport
+	ActiveSessions uint8
+
+	// Volatile (active) access settings, only valid if AccessAvailable is `true`
+	AccessAvailable        bool
+	AccessMode             ChannelAccessMode
+	PrivilegeLimit         PrivilegeLevel
+	AlertingDisabled       bool
+	PerMessageAuthDisabled bool
+	UserLevelAuthDisabled  bool
+
+	// Authentication capabilities, nil for session-less channels or if not provided by BMC
+	AuthCapabilities *GetChannelAuthCapabilitiesCommand
+}
+
+// IsLAN Returns `true` if channel is a LAN channel.
+func (ch *Channel) IsLAN() bool {
+	return ch.MediumType == ChannelMediumLAN || ch.MediumType == ChannelMediumOtherLAN
+}
+
+// WeakSettings Returns descriptions of insecure settings of an enabled session-based channel.
+func (ch *Channel) WeakSettings() []string {
+	var weak []string
+	if ch.AccessAvailable {
+		if ch.AccessMode == ChannelAccessModeDisabled {
+			return nil
+		}
+		if ch.PerMessageAuthDisabled {
+			weak = append(weak, "per-message authentication disabled")
+		}
+		if ch.UserLevelAuthDisabled {
+			weak = append(weak, "user level authentication disabled")
+		}
+	}
+
+	if auth := ch.AuthCapabilities; auth != nil {
+		if auth.AnonymousLogin {
+			weak = append(weak, "anonymous login enabled")
+		}
+		if auth.NullUsernames {
+			weak = append(weak, "null usernames enabled")
+		}
+		for _, t := range []authType{authTypeNone, authTypeMD2, authTypePassword} {
+			if auth.IsSupportedAuthType(t) {
+				weak = append(weak, "authentication type "+t.String()+" enabled")
+			}
+		}
+		if auth.SupportIPMIV2_0 && !auth.KGNonZero {
+			weak = append(weak, "KG is set to default (all zeros)")
+		}
+	}
+	return weak
+}
+
+// ChannelGetAll Returns all channels (0-15) implemented by BMC.
+func ChannelGetAll(c *Client) ([]*Channel, error) {
+	channels := make([]*Channel, 0)
+
+	for n := uint8(0); n <= channelNumberSystemInterface; n++ {
+		if n == channelNumberCurrent {
+			// Alias of the channel this request is received over
+			continue
+		}
+
+		gci := &GetChannelInfoCommand{ChannelNumber: n}
+		if err := c.Execute(gci); err != nil {
+			if _, ok := err.(*CommandError); ok {
+				// Channel is not implemented
+				continue
+			}
+			return nil, err
+		}
+
+		ch := &Channel{
+			Number:         gci.ActualChannelNumber,
+			MediumType:     gci.MediumType,
+			ProtocolType:   gci.ProtocolType,
+			SessionSupport: gci.SessionSupport,
+			ActiveSessions: gci.ActiveSessionCount,
+		}
+
+		gca := &GetChannelAccessCommand{ChannelNumber: n, AccessType: ChannelAccessVolatile}
+		if err := c.Execute(gca); err == nil {
+			ch.AccessAvailable = true
+			ch.AccessMode = gca.AccessMode
+			ch.PrivilegeLimit = gca.PrivilegeLimit
+			ch.AlertingDisabled = gca.AlertingDisabled
+			ch.PerMessageAuthDisabled = gca.PerMessageAuthDisabled
+			ch.UserLevelAuthDisabled = gca.UserLevelAuthDisabled
+		} else if _, ok := err.(*CommandError); !ok {
+			return nil, err
+		}
+
+		if ch.SessionSupport != ChannelSessionLess {
+			cac := &GetChannelAuthCapabilitiesCommand{ReqChannelNumber: n | 0x80, PrivilegeLevel: PrivilegeAdministrator}
+			err := c.Execute(cac)
+			if _, ok := err.(*CommandError); ok {
+				// Retry, without requesting IPMI V2
+				cac.ReqChannelNumber = n
+				err = c.Execute(cac)
+			}
+			if err == nil {
+				ch.AuthCapabilities = cac
+			} else if _, ok := err.(*CommandError); !ok {
+				return nil, err
+			}
+		}
+
+		channels = append(channels, ch)
+	}
+
+	return channels, nil
+}
@@ -0,0 +1,283 @@
+package ipmigo
+
+import (
+	"fmt"
+)
+
+const (
+	channelNumberCurrent         = 0x0e
+	channelNumberSystemInterface = 0x0f
+)
+
+// ChannelMediumType Channel Medium Type Numbers (Table 6-3)
+type ChannelMediumType uint8
+
+const (
+	ChannelMediumIPMB            ChannelMediumType = 0x01
+	ChannelMediumICMBv10         ChannelMediumType = 0x02
+	ChannelMediumICMBv09         ChannelMediumType = 0x03
+	ChannelMediumLAN             ChannelMediumType = 0x04
+	ChannelMediumSerial          ChannelMediumType = 0x05
+	ChannelMediumOtherLAN        ChannelMediumType = 0x06
+	ChannelMediumPCISMBus        ChannelMediumType = 0x07
+	ChannelMediumSMBusV1         ChannelMediumType = 0x08
+	ChannelMediumSMBusV2         ChannelMediumType = 0x09
+	ChannelMediumUSBv1           ChannelMediumType = 0x0a
+	ChannelMediumUSBv2           ChannelMediumType = 0x0b
+	ChannelMediumSystemInterface ChannelMediumType = 0x0c
+)
+
+func (t ChannelMediumType) String() string {
+	switch t {
+	case ChannelMediumIPMB:
+		return "IPMB (I2C)"
+	case ChannelMediumICMBv10:
+		return "ICMB v1.0"
+	case ChannelMediumICMBv09:
+		return "ICMB v0.9"
+	case ChannelMediumLAN:
+		return "802.3 LAN"
+	case ChannelMediumSerial:
+		return "Asynch. Serial/Modem (RS-232)"
+	case ChannelMediumOtherLAN:
+		return "Other LAN"
+	case ChannelMediumPCISMBus:
+		return "PCI SMBus"
+	case ChannelMediumSMBusV1:
+		return "SMBus v1.0/1.1"
+	case ChannelMediumSMBusV2:
+		return "SMBus v2.0"
+	case ChannelMediumUSBv1:
+		return "USB 1.x"
+	case ChannelMediumUSBv2:
+		return "USB 2.x"
+	case ChannelMediumSystemInterface:
+		return "System Interface (KCS, SMIC, or BT)"
+	default:
+		if t >= 0x60 && t <= 0x7f {
+			return fmt.Sprintf("OEM(0x%02x)", uint8(t))
+		}
+		return fmt.Sprintf("Reserved(0x%02x)", uint8(t))
+	}
+}
+
+// ChannelProtocolType Channel Protocol Type Numbers (Table 6-2)
+type ChannelProtocolType uint8
+
+const (
+	ChannelProtocolIPMB      ChannelProtocolType = 0x01
+	ChannelProtocolICMB      ChannelProtocolType = 0x02
+	ChannelProtocolIPMISMBus ChannelProtocolType = 0x04
+	ChannelProtocolKCS       ChannelProtocolType = 0x05
+	ChannelProtocolSMIC      ChannelProtocolType = 0x06
+	ChannelProtocolBT10      ChannelProtocolType = 0x07
+	ChannelProtocolBT15      ChannelProtocolType = 0x08
+	ChannelProtocolTMode     ChannelProtocolType = 0x09
+)
+
+func (t ChannelProtocolType) String() string {
+	switch t {
+	case 0x00:
+		return "n/a"
+	case ChannelProtocolIPMB:
+		return "IPMB-1.0"
+	case ChannelProtocolICMB:
+		return "ICMB-1.0"
+	case ChannelProtocolIPMISMBus:
+		return "IPMI-SMBus"
+	case ChannelProtocolKCS:
+		return "KCS"
+	case ChannelProtocolSMIC:
+		return "SMIC"
+	case ChannelProtocolBT10:
+		return "BT-10"
+	case ChannelProtocolBT15:
+		return "BT-15"
+	case ChannelProtocolTMode:
+		return "TMode"
+	default:
+		if t >= 0x1c && t <= 0x1f {
+			return fmt.Sprintf("OEM(0x%02x)", uint8(t))
+		}
+		return fmt.Sprintf("Reserved(0x%02x)", uint8(t))
+	}
+}
+
+// ChannelSessionSupport Channel session support (Section 22.24)
+type ChannelSessionSupport uint8
+
+const (
+	ChannelSessionLess   ChannelSessionSupport = 0x00
+	ChannelSingleSession ChannelSessionSupport = 0x01
+	ChannelMultiSession  ChannelSessionSupport = 0x02
+	ChannelSessionBased  ChannelSessionSupport = 0x03
+)
+
+func (s ChannelSessionSupport) String() string {
+	switch s {
+	case ChannelSessionLess:
+		return "session-less"
+	case ChannelSingleSession:
+		return "single-session"
+	case ChannelMultiSession:
+		return "multi-session"
+	default:
+		return "session-based"
+	}
+}
+
+// ChannelAccessMode Channel access mode (Section 22.22)
+type ChannelAccessMode uint8
+
+const (
+	ChannelAccessModeDisabled        ChannelAccessMode = 0x00
+	ChannelAccessModePreBootOnly     ChannelAccessMode = 0x01
+	ChannelAccessModeAlwaysAvailable ChannelAccessMode = 0x02
+	ChannelAccessModeShared          ChannelAccessMode = 0x03
+)
+
+func (m ChannelAccessMode) String() string {
+	switch m {
+	case ChannelAccessModeDisabled:
+		return "disabled"
+	case ChannelAccessModePreBootOnly:
+		return "pre-boot only"
+	case ChannelAccessModeAlwaysAvailable:
+		return "always available"
+	case ChannelAccessModeShared:
+		return "shared"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(m))
+	}
+}
+
+// ChannelAccessType Selects the non-volatile or the volatile (active) channel access settings
+type ChannelAccessType uint8
+
+const (
+	ChannelAccessNoChange    ChannelAccessType = 0x00 // Set only: don't set or change
+	ChannelAccessNonVolatile ChannelAccessType = 0x01
+	ChannelAccessVolatile    ChannelAccessType = 0x02
+)
+
+// GetChannelInfoCommand Get Channel Info Command (Section 22.24)
+type GetChannelInfoCommand struct {
+	// Request Data
+	ChannelNumber uint8 // 0x0e: this channel
+
+	// Response Data
+	ActualChannelNumber uint8
+	MediumType          ChannelMediumType
+	ProtocolType        ChannelProtocolType
+	SessionSupport      ChannelSessionSupport
+	ActiveSessionCount  uint8
+	VendorID            uint32
+	AuxiliaryInfo       [2]byte
+}
+
+func (c *GetChannelInfoCommand) Name() string           { return "Get Channel Info" }
+func (c *GetChannelInfoCommand) Code() uint8            { return 0x42 }
+func (c *GetChannelInfoCommand) NetFnRsLUN() NetFnRsLUN { return NewNetFnRsLUN(NetFnAppReq, 0) }
+func (c *GetChannelInfoCommand) String() string         { return cmdToJSON(c) }
+
+func (c *GetChannelInfoCommand) Marshal() ([]byte, error) {
+	return []byte{c.ChannelNumber & 0x0f}, nil
+}
+
+func (c *GetChannelInfoCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 9); err != nil {
+		return nil, err
+	}
+	c.ActualChannelNumber = buf[0] & 0x0f
+	c.MediumType = ChannelMediumType(buf[1] & 0x7f)
+	c.ProtocolType = ChannelProtocolType(buf[2] & 0x1f)
+	c.SessionSupport = ChannelSessionSupport(buf[3] >> 6)
+	c.ActiveSessionCount = buf[3] & 0x3f
+	c.VendorID = uint32(buf[4]) | uint32(buf[5])<<8 | uint32(buf[6])<<16
+	copy(c.AuxiliaryInfo[:], buf[7:9])
+	return buf[9:], nil
+}
+
+// GetChannelAccessCommand Get Channel Access Command (Section 22.23)
+type GetChannelAccessCommand struct {
+	// Request Data
+	ChannelNumber uint8
+	AccessType    ChannelAccessType // non-volatile or volatile settings
+
+	// Response Data
+	AlertingDisabled       bool
+	PerMessageAuthDisabled bool
+	UserLevelAuthDisabled  bool
+	AccessMode             ChannelAccessMode
+	PrivilegeLimit         PrivilegeLevel
+}
+
+func (c *GetChannelAccessCommand) Name() string           { return "Get Channel Access" }
+func (c *GetChannelAccessCommand) Code() uint8            { return 0x41 }
+func (c *GetChannelAccessCommand) NetFnRsLUN() NetFnRsLUN { return NewNetFnRsLUN(NetFnAppReq, 0) }
+func (c *GetChannelAccessCommand) String() string         { return cmdToJSON(c) }
+
+func (c *GetChannelAccessCommand) Marshal() ([]byte, error) {
+	t := c.AccessType
+	if t != ChannelAccessNonVolatile {
+		t = ChannelAccessVolatile
+	}
+	return []byte{c.ChannelNumber & 0x0f, byte(t) << 6}, nil
+}
+
+func (c *GetChannelAccessCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 2); err != nil {
+		return nil, err
+	}
+	c.AlertingDisabled = buf[0]&0x20 != 0
+	c.PerMessageAuthDisabled = buf[0]&0x10 != 0
+	c.UserLevelAuthDisabled = buf[0]&0x08 != 0
+	c.AccessMode = ChannelAccessMode(buf[0] & 0x07)
+	c.PrivilegeLimit = PrivilegeLevel(buf[1] & 0x0f)
+	return buf[2:], nil
+}
+
+// SetChannelAccessCommand Set Channel Access Command (Section 22.22)
+type SetChannelAccessCommand struct {
+	// Request Data
+	ChannelNumber          uint8
+	AccessType             ChannelAccessType // where to store the access settings (ChannelAccessNoChange keeps them)
+	AlertingDisabled       bool
+	PerMessageAuthDisabled bool
+	UserLevelAuthDisabled  bool
+	AccessMode             ChannelAccessMode
+	PrivilegeLimitType     ChannelAccessType // where to store the privilege limit (ChannelAccessNoChange keeps it)
+	PrivilegeLimit         PrivilegeLevel
+}
+
+func (c *SetChannelAccessCommand) Name() string           { return "Set Channel Access" }
+func (c *SetChannelAccessCommand) Code() uint8            { return 0x40 }
+func (c *SetChannelAccessCommand) NetFnRsLUN() NetFnRsLUN { return NewNetFnRsLUN(NetFnAppReq, 0) }
+func (c *SetChannelAccessCommand) String() string         { return cmdToJSON(c) }
+
+func (c *SetChannelAccessCommand) Marshal() ([]byte, error) {
+	for _, t := range []ChannelAccessType{c.AccessType, c.PrivilegeLimitType} {
+		if t > ChannelAccessVolatile {
+			return nil, &ArgumentError{
+				Value:   t,
+				Message: "Invalid channel access type",
+			}
+		}
+	}
+
+	access := byte(c.AccessType)<<6 | byte(c.AccessMode)&0x07
+	if c.AlertingDisabled {
+		access |= 0x20
+	}
+	if c.PerMessageAuthDisabled {
+		access |= 0x10
+	}
+	if c.UserLevelAuthDisabled {
+		access |= 0x08
+	}
+	return []byte{c.ChannelNumber & 0x0f, access, byte(c.PrivilegeLimitType)<<6 | byte(c.PrivilegeLimit)&0x0f}, nil
+}
+
+func (c *SetChannelAccessCommand) Unmarshal(buf []byte) ([]byte, error) {
+	return buf, nil
+}
@@ -2,9 +2,94 @@ package ipmigo
 
 import (
 	"encoding/binary"
+	"fmt"
 	"time"
 )
 
+// GetChassisCapabilitiesCommand Get Chassis Capabilities Command (Section 28.1)
+type GetChassisCapabilitiesCommand struct {
+	// Response Data
+	PowerInterlock        bool // Provides power interlock
+	DiagnosticInterrupt   bool // Provides diagnostic interrupt (FP NMI)
+	FrontPanelLockout     bool // Provides front panel lockout
+	IntrusionSensor       bool // Provides intrusion (physical security) sensor
+	FRUDeviceAddress      uint8
+	SDRDeviceAddress      uint8
+	SELDeviceAddress      uint8
+	SMDeviceAddress       uint8 // System Management Device Address
+	BridgeDeviceAddress   uint8 // Only valid if BridgeDeviceSupported
+	BridgeDeviceSupported bool
+}
+
+func (c *GetChassisCapabilitiesCommand) Name() string { return "Get Chassis Capabilities" }
+func (c *GetChassisCapabilitiesCommand) Code() uint8  { return 0x00 }
+
+func (c *GetChassisCapabilitiesCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnChassisReq, 0)
+}
+
+func (c *GetChassisCapabilitiesCommand) String() string           { return cmdToJSON(c) }
+func (c *GetChassisCapabilitiesCommand) Marshal() ([]byte, error) { return []byte{}, nil }
+
+func (c *GetChassisCapabilitiesCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 5); err != nil {
+		return nil, err
+	}
+	c.PowerInterlock = buf[0]&0x08 != 0
+	c.DiagnosticInterrupt = buf[0]&0x04 != 0
+	c.FrontPanelLockout = buf[0]&0x02 != 0
+	c.IntrusionSensor = buf[0]&0x01 != 0
+	c.FRUDeviceAddress = buf[1]
+	c.SDRDeviceAddress = buf[2]
+	c.SELDeviceAddress = buf[3]
+	c.SMDeviceAddress = buf[4]
+	if c.BridgeDeviceSupported = len(buf) >= 6; c.BridgeDeviceSupported {
+		c.BridgeDeviceAddress = buf[5]
+		return buf[6:], nil
+	}
+	return buf[5:], nil
+}
+
+// SetChassisCapabilitiesCommand Set Chassis Capabilities Command (Section 28.7)
+type SetChassisCapabilitiesCommand struct {
+	// Request Data
+	FrontPanelLockout   bool // Provides front panel lockout
+	IntrusionSensor     bool // Provides intrusion (physical security) sensor
+	FRUDeviceAddress    uint8
+	SDRDeviceAddress    uint8
+	SELDeviceAddress    uint8
+	SMDeviceAddress     uint8 // System Management Device Address
+	BridgeDeviceAddress uint8 // Optional, 0 is not sent
+}
+
+func (c *SetChassisCapabilitiesCommand) Name() string { return "Set Chassis Capabilities" }
+func (c *SetChassisCapabilitiesCommand) Code() uint8  { return 0x05 }
+
+func (c *SetChassisCapabilitiesCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnChassisReq, 0)
+}
+
+func (c *SetChassisCapabilitiesCommand) String() string { return cmdToJSON(c) }
+
+func (c *SetChassisCapabilitiesCommand) Marshal() ([]byte, error) {
+	var flags uint8
+	if c.FrontPanelLockout {
+		flags |= 0x02
+	}
+	if c.IntrusionSensor {
+		flags |= 0x01
+	}
+	cmd := []byte{flags, c.FRUDeviceAddress, c.SDRDeviceAddress, c.SELDeviceAddress, c.SMDeviceAddress}
+	if c.BridgeDeviceAddress != 0 {
+		cmd = append(cmd, c.BridgeDeviceAddress)
+	}
+	return cmd, nil
+}
+
+func (c *SetChassisCapabilitiesCommand) Unmarshal(buf []byte) ([]byte, error) {
+	return buf, nil
+}
+
 // GetChassisStatusCommand Get Chassis Status Command (Section 28.2)
 type GetChassisStatusCommand struct {
 	// Response Data
@@ -13,7 +98,7 @@ type GetChassisStatusCommand struct {
 	PowerInterlock          bool
 	PowerFault              bool
 	PowerControlFault       bool
-	PowerRestorePolicy      uint8 // (See Table 28-3)
+	PowerRestorePolicy      PowerRestorePolicy
 	LastPowerEventACFailed  bool
 	LastPowerEventOverload  bool
 	LastPowerEventInterlock bool
@@ -23,6 +108,21 @@ type GetChassisStatusCommand struct {
 	FrontPanelLockoutActive bool
 	DriveFault              bool
 	CoolingFanFault         bool
+	IdentifySupported       bool
+	IdentifyState           ChassisIdentifyState // Only valid if IdentifySupported
+
+	// Front Panel Button Capabilities and disable/enable status (optional)
+	FrontPanelButtonsSupported bool
+	FrontPanelButtons          struct {
+		StandbyDisableAllowed    bool
+		DiagnosticDisableAllowed bool
+		ResetDisableAllowed      bool
+		PowerOffDisableAllowed   bool
+		StandbyDisabled          bool
+		DiagnosticDisabled       bool
+		ResetDisabled            bool
+		PowerOffDisabled         bool
+	}
 }
 
 func (c *GetChassisStatusCommand) Name() string             { return "Get Chassis Status" }
@@ -40,7 +140,7 @@ func (c *GetChassisStatusCommand) Unmarshal(buf []byte) ([]byte, error) {
 	c.PowerInterlock = buf[0]&0x04 != 0
 	c.PowerFault = buf[0]&0x08 != 0
 	c.PowerControlFault = buf[0]&0x10 != 0
-	c.PowerRestorePolicy = buf[0] & 0x60 >> 5
+	c.PowerRestorePolicy = PowerRestorePolicy(buf[0] & 0x60 >> 5)
 
 	c.LastPowerEventACFailed = buf[1]&0x01 != 0
 	c.LastPowerEventOverload = buf[1]&0x02 != 0
@@ -52,9 +152,106 @@ func (c *GetChassisStatusCommand) Unmarshal(buf []byte) ([]byte, error) {
 	c.FrontPanelLockoutActive = buf[2]&0x02 != 0
 	c.DriveFault = buf[2]&0x04 != 0
 	c.CoolingFanFault = buf[2]&0x08 != 0
+	c.IdentifySupported = buf[2]&0x40 != 0
+	c.IdentifyState = ChassisIdentifyState(buf[2] & 0x30 >> 4)
+
+	if c.FrontPanelButtonsSupported = len(buf) >= 4; c.FrontPanelButtonsSupported {
+		c.FrontPanelButtons.StandbyDisableAllowed = buf[3]&0x80 != 0
+		c.FrontPanelButtons.DiagnosticDisableAllowed = buf[3]&0x40 != 0
+		c.FrontPanelButtons.ResetDisableAllowed = buf[3]&0x20 != 0
+		c.FrontPanelButtons.PowerOffDisableAllowed = buf[3]&0x10 != 0
+		c.FrontPanelButtons.StandbyDisabled = buf[3]&0x08 != 0
+		c.FrontPanelButtons.DiagnosticDisabled = buf[3]&0x04 != 0
+		c.FrontPanelButtons.ResetDisabled = buf[3]&0x02 != 0
+		c.FrontPanelButtons.PowerOffDisabled = buf[3]&0x01 != 0
+	}
 	return nil, nil
 }
 
+// ChassisIdentifyState Chassis Identify State (Section 28.2)
+type ChassisIdentifyState uint8
+
+const (
+	ChassisIdentifyOff        ChassisIdentifyState = 0x00
+	ChassisIdentifyTemporary  ChassisIdentifyState = 0x01 // Timed on
+	ChassisIdentifyIndefinite ChassisIdentifyState = 0x02
+)
+
+func (s ChassisIdentifyState) String() string {
+	switch s {
+	case ChassisIdentifyOff:
+		return "off"
+	case ChassisIdentifyTemporary:
+		return "temporary on"
+	case ChassisIdentifyIndefinite:
+		return "indefinite on"
+	default:
+		return fmt.Sprintf("Reserved(%d)", uint8(s))
+	}
+}
+
+// ChassisIdentifyCommand Chassis Identify Command (Section 28.5)
+type ChassisIdentifyCommand struct {
+	// Request Data
+	IdentifyInterval uint8 // Identify interval in seconds, 0 turns off identify
+	ForceOn          bool  // Turn on identify indefinitely, IdentifyInterval is ignored
+}
+
+func (c *ChassisIdentifyCommand) Name() string           { return "Chassis Identify" }
+func (c *ChassisIdentifyCommand) Code() uint8            { return 0x04 }
+func (c *ChassisIdentifyCommand) NetFnRsLUN() NetFnRsLUN { return NewNetFnRsLUN(NetFnChassisReq, 0) }
+func (c *ChassisIdentifyCommand) String() string         { return cmdToJSON(c) }
+
+func (c *ChassisIdentifyCommand) Marshal() ([]byte, error) {
+	if c.ForceOn {
+		return []byte{c.IdentifyInterval, 0x01}, nil
+	}
+	return []byte{c.IdentifyInterval}, nil
+}
+
+func (c *ChassisIdentifyCommand) Unmarshal(buf []byte) ([]byte, error) {
+	return buf, nil
+}
+
+// SetFrontPanelEnablesCommand Set Front Panel Button Enables Command (Section 28.6)
+type SetFrontPanelEnablesCommand struct {
+	// Request Data
+	DisableStandby    bool // Disable Standby (sleep) button
+	DisableDiagnostic bool // Disable Diagnostic Interrupt button
+	DisableReset      bool // Disable Reset button
+	DisablePowerOff   bool // Disable Power off button (or power on/off button)
+}
+
+func (c *SetFrontPanelEnablesCommand) Name() string { return "Set Front Panel Button Enables" }
+func (c *SetFrontPanelEnablesCommand) Code() uint8  { return 0x0a }
+
+func (c *SetFrontPanelEnablesCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnChassisReq, 0)
+}
+
+func (c *SetFrontPanelEnablesCommand) String() string { return cmdToJSON(c) }
+
+func (c *SetFrontPanelEnablesCommand) Marshal() ([]byte, error) {
+	var b uint8
+	if c.DisableStandby {
+		b |= 0x08
+	}
+	if c.DisableDiagnostic {
+		b |= 0x04
+	}
+	if c.DisableReset {
+		b |= 0x02
+	}
+	if c.DisablePowerOff {
+		b |= 0x01
+	}
+	return []byte{b}, nil
+}
+
+func (c *SetFrontPanelEnablesCommand) Unmarshal(buf []byte) ([]byte, error) {
+	return buf, nil
+}
+
 type ChassisControl uint8
 
 const (
@@ -83,10 +280,142 @@ func (c *SetChassisControlCommand) Unmarshal(buf []byte) ([]byte, error) {
 	return nil, nil
 }
 
+// PowerRestorePolicy Power Restore Policy (Section 28.2, 28.8)
+type PowerRestorePolicy uint8
+
+const (
+	PowerRestorePolicyAlwaysOff PowerRestorePolicy = 0x00 // Chassis stays powered off after AC/mains returns
+	PowerRestorePolicyPrevious  PowerRestorePolicy = 0x01 // Power is restored to the state that was in effect when AC/mains was lost
+	PowerRestorePolicyAlwaysOn  PowerRestorePolicy = 0x02 // Chassis always powers up after AC/mains returns
+	PowerRestorePolicyUnknown   PowerRestorePolicy = 0x03
+)
+
+func (p PowerRestorePolicy) String() string {
+	switch p {
+	case PowerRestorePolicyAlwaysOff:
+		return "always-off"
+	case PowerRestorePolicyPrevious:
+		return "previous"
+	case PowerRestorePolicyAlwaysOn:
+		return "always-on"
+	default:
+		return "unknown"
+	}
+}
+
+// SetPowerRestorePolicyCommand Set Power Restore Policy Command (Section 28.8)
+type SetPowerRestorePolicyCommand struct {
+	// Request Data
+	Policy   PowerRestorePolicy
+	NoChange bool // Only get the supported policies
+
+	// Response Data
+	SupportAlwaysOff bool
+	SupportPrevious  bool
+	SupportAlwaysOn  bool
+}
+
+func (c *SetPowerRestorePolicyCommand) Name() string { return "Set Power Restore Policy" }
+func (c *SetPowerRestorePolicyCommand) Code() uint8  { return 0x06 }
+
+func (c *SetPowerRestorePolicyCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnChassisReq, 0)
+}
+
+func (c *SetPowerRestorePolicyCommand) String() string { return cmdToJSON(c) }
+
+func (c *SetPowerRestorePolicyCommand) Marshal() ([]byte, error) {
+	if c.NoChange {
+		return []byte{0x03}, nil
+	}
+	if c.Policy > PowerRestorePolicyAlwaysOn {
+		return nil, &ArgumentError{
+			Value:   c.Policy,
+			Message: "Invalid power restore policy",
+		}
+	}
+	return []byte{uint8(c.Policy)}, nil
+}
+
+func (c *SetPowerRestorePolicyCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 1); err != nil {
+		return nil, err
+	}
+	c.SupportAlwaysOff = buf[0]&0x01 != 0
+	c.SupportPrevious = buf[0]&0x02 != 0
+	c.SupportAlwaysOn = buf[0]&0x04 != 0
+	return buf[1:], nil
+}
+
+// SupportedPolicies Returns the power restore policies supported by BMC.
+func (c *SetPowerRestorePolicyCommand) SupportedPolicies() []PowerRestorePolicy {
+	var policies []PowerRestorePolicy
+	if c.SupportAlwaysOff {
+		policies = append(policies, PowerRestorePolicyAlwaysOff)
+	}
+	if c.SupportPrevious {
+		policies = append(policies, PowerRestorePolicyPrevious)
+	}
+	if c.SupportAlwaysOn {
+		policies = append(policies, PowerRestorePolicyAlwaysOn)
+	}
+	return policies
+}
+
+// RestartCause System Restart Cause (Table 28-11)
+type RestartCause uint8
+
+const (
+	RestartCauseUnknown         RestartCause = 0x00
+	RestartCauseChassisControl  RestartCause = 0x01
+	RestartCauseResetButton     RestartCause = 0x02
+	RestartCausePowerButton     RestartCause = 0x03
+	RestartCauseWatchdog        RestartCause = 0x04
+	RestartCauseOEM             RestartCause = 0x05
+	RestartCauseAlwaysRestore   RestartCause = 0x06
+	RestartCauseRestorePrevious RestartCause = 0x07
+	RestartCausePEFReset        RestartCause = 0x08
+	RestartCausePEFPowerCycle   RestartCause = 0x09
+	RestartCauseSoftReset       RestartCause = 0x0a
+	RestartCauseRTCWakeup       RestartCause = 0x0b
+)
+
+func (r RestartCause) String() string {
+	switch r {
+	case RestartCauseUnknown:
+		return "unknown"
+	case RestartCauseChassisControl:
+		return "Chassis Control command"
+	case RestartCauseResetButton:
+		return "reset via pushbutton"
+	case RestartCausePowerButton:
+		return "power-up via power pushbutton"
+	case RestartCauseWatchdog:
+		return "watchdog expiration"
+	case RestartCauseOEM:
+		return "OEM"
+	case RestartCauseAlwaysRestore:
+		return "power-up due to 'always restore' power restore policy"
+	case RestartCauseRestorePrevious:
+		return "power-up due to 'restore previous power state' power restore policy"
+	case RestartCausePEFReset:
+		return "reset via PEF"
+	case RestartCausePEFPowerCycle:
+		return "power-cycle via PEF"
+	case RestartCauseSoftReset:
+		return "soft reset"
+	case RestartCauseRTCWakeup:
+		return "power-up via RTC wakeup"
+	default:
+		return fmt.Sprintf("Reserved(%d)", uint8(r))
+	}
+}
+
 // GetSystemRestartCauseCommand Get System Restart Cause Command (Section 28.11)
 type GetSystemRestartCauseCommand struct {
 	// Response Data
-	RestartCause uint8 // (See Table 28-11)
+	RestartCause  RestartCause
+	ChannelNumber uint8 // Channel the command was received on that caused the restart
 }
 
 func (c *GetSystemRestartCauseCommand) Name() string { return "Get System Restart Cause" }
@@ -103,8 +432,12 @@ func (c *GetSystemRestartCauseCommand) Unmarshal(buf []byte) ([]byte, error) {
 	if err := cmdValidateLength(c, buf, 1); err != nil {
 		return nil, err
 	}
-	c.RestartCause = buf[0]
-	return buf[1:], nil
+	c.RestartCause = RestartCause(buf[0] & 0x0f)
+	if len(buf) < 2 {
+		return nil, nil
+	}
+	c.ChannelNumber = buf[1] & 0x0f
+	return buf[2:], nil
 }
 
 // GetPOHCounterCommand Get POH Counter Command (Section 28.14)
@@ -132,3 +465,83 @@ func (c *GetPOHCounterCommand) Unmarshal(buf []byte) ([]byte, error) {
 func (c *GetPOHCounterCommand) PowerOnHours() time.Duration {
 	return time.Duration(c.MinutesPerCount) * time.Duration(c.Counter) * time.Minute
 }
+
+// BootOptionParameter Boot Option Parameter selector (Table 28-14)
+type BootOptionParameter uint8
+
+const (
+	BootOptionSetInProgress        BootOptionParameter = 0x00
+	BootOptionServicePartition     BootOptionParameter = 0x01
+	BootOptionServicePartitionScan BootOptionParameter = 0x02
+	BootOptionFlagValidBitClearing BootOptionParameter = 0x03
+	BootOptionInfoAcknowledge      BootOptionParameter = 0x04
+	BootOptionFlags                BootOptionParameter = 0x05
+	BootOptionInitiatorInfo        BootOptionParameter = 0x06
+	BootOptionInitiatorMailbox     BootOptionParameter = 0x07
+)
+
+// GetSystemBootOptionsCommand Get System Boot Options Command (Section 28.13)
+type GetSystemBootOptionsCommand struct {
+	// Request Data
+	ParameterSelector BootOptionParameter
+	SetSelector       uint8
+	BlockSelector     uint8
+
+	// Response Data
+	ParameterVersion uint8
+	ParameterValid   bool // `false` if parameter is marked invalid / locked
+	Data             []byte
+}
+
+func (c *GetSystemBootOptionsCommand) Name() string { return "Get System Boot Options" }
+func (c *GetSystemBootOptionsCommand) Code() uint8  { return 0x09 }
+
+func (c *GetSystemBootOptionsCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnChassisReq, 0)
+}
+
+func (c *GetSystemBootOptionsCommand) String() string { return cmdToJSON(c) }
+
+func (c *GetSystemBootOptionsCommand) Marshal() ([]byte, error) {
+	return []byte{uint8(c.ParameterSelector) & 0x7f, c.SetSelector, c.BlockSelector}, nil
+}
+
+func (c *GetSystemBootOptionsCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 2); err != nil {
+		return nil, err
+	}
+	c.ParameterVersion = buf[0] & 0x0f
+	c.ParameterValid = buf[1]&0x80 == 0
+	c.Data = make([]byte, len(buf)-2)
+	copy(c.Data, buf[2:])
+	return nil, nil
+}
+
+// SetSystemBootOptionsCommand Set System Boot Options Command (Section 28.12)
+type SetSystemBootOptionsCommand struct {
+	// Request Data
+	ParameterSelector BootOptionParameter
+	MarkInvalid       bool // Mark parameter invalid / locked
+	Data              []byte
+}
+
+func (c *SetSystemBootOptionsCommand) Name() string { return "Set System Boot Options" }
+func (c *SetSystemBootOptionsCommand) Code() uint8  { return 0x08 }
+
+func (c *SetSystemBootOptionsCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnChassisReq, 0)
+}
+
+func (c *SetSystemBootOptionsCommand) String() string { return cmdToJSON(c) }
+
+func (c *SetSystemBootOptionsCommand) Marshal() ([]byte, error) {
+	p := uint8(c.ParameterSelector) & 0x7f
+	if c.MarkInvalid {
+		p |= 0x80
+	}
+	return append([]byte{p}, c.Data...), nil
+}
+
+func (c *SetSystemBootOptionsCommand) Unmarshal(buf []byte) ([]byte, error) {
+	return buf, nil
+}
@@ -15,6 +15,11 @@ type GetDeviceIDCommand struct {
 	SupportDeviceSEL      bool
 	SupportDeviceFRU      bool
 	SupportDeviceChassis  bool
+	SupportDeviceBridge   bool
+	SupportEventGenerator bool // IPMB Event Generator
+	SupportEventReceiver  bool // IPMB Event Receiver
+	ManufacturerID        uint32
+	ProductID             uint16
 	// Other fields are omitted because it is not used
 }
 
@@ -40,6 +45,11 @@ func (c *GetDeviceIDCommand) Unmarshal(buf []byte) ([]byte, error) {
 	c.SupportDeviceSEL = buf[5]&0x04 != 0
 	c.SupportDeviceFRU = buf[5]&0x08 != 0
 	c.SupportDeviceChassis = buf[5]&0x80 != 0
+	c.SupportDeviceBridge = buf[5]&0x40 != 0
+	c.SupportEventGenerator = buf[5]&0x20 != 0
+	c.SupportEventReceiver = buf[5]&0x10 != 0
+	c.ManufacturerID = uint32(buf[6]) | uint32(buf[7])<<8 | uint32(buf[8]&0x0f)<<16
+	c.ProductID = uint16(buf[9]) | uint16(buf[10])<<8
 
 	if l := len(buf); l < 15 {
 		return buf[11:], nil
@@ -0,0 +1,53 @@
+package ipmigo
+
+// I2CBusType Bus type of Master Write-Read Command
+type I2CBusType uint8
+
+const (
+	I2CBusPublic  I2CBusType = 0x00 // e.g. IPMB
+	I2CBusPrivate I2CBusType = 0x01
+)
+
+// MasterWriteReadCommand Master Write-Read Command (Section 22.11)
+type MasterWriteReadCommand struct {
+	// Request Data
+	BusType       I2CBusType
+	BusID         uint8  // bits 2:0, 0-based
+	ChannelNumber uint8  // bits 3:0, ignored for private bus
+	SlaveAddress  uint8  // bits 7:1 Slave Address (7-bit), bit 0 - reserved
+	ReadCount     uint8  // Number of bytes to read, 0 = write only
+	DataWrite     []byte // Data to write, empty for read only
+
+	// Response Data
+	Data []byte // Read data
+}
+
+func (c *MasterWriteReadCommand) Name() string { return "Master Write-Read" }
+func (c *MasterWriteReadCommand) Code() uint8  { return 0x52 }
+
+func (c *MasterWriteReadCommand) Input() []byte  { return c.DataWrite }
+func (c *MasterWriteReadCommand) Output() []byte { return c.Data }
+
+func (c *MasterWriteReadCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnAppReq, 0)
+}
+
+func (c *MasterWriteReadCommand) String() string { return cmdToJSON(c) }
+
+func (c *MasterWriteReadCommand) Marshal() ([]byte, error) {
+	bus := (c.ChannelNumber&0x0f)<<4 | (c.BusID&0x07)<<1 | uint8(c.BusType)&0x01
+	cmd := []byte{bus, c.SlaveAddress & 0xfe, c.ReadCount}
+	if len(c.DataWrite) > 0 {
+		cmd = append(cmd, c.DataWrite...)
+	}
+	return cmd, nil
+}
+
+func (c *MasterWriteReadCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, int(c.ReadCount)); err != nil {
+		return nil, err
+	}
+	c.Data = make([]byte, c.ReadCount)
+	copy(c.Data, buf)
+	return buf[c.ReadCount:], nil
+}
@@ -5,41 +5,67 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net"
+	"strings"
 )
 
-// Get Channel Authentication Capabilities Command (Section 22.13)
-type channelAuthCapCommand struct {
+// GetChannelAuthCapabilitiesCommand Get Channel Authentication Capabilities Command (Section 22.13)
+type GetChannelAuthCapabilitiesCommand struct {
 	// Request Data
-	ReqChannelNumber uint8
+	ReqChannelNumber uint8 // bits 3:0 channel number (0x0e: this channel), bit 7 get IPMI v2.0+ extended data
 	PrivilegeLevel   PrivilegeLevel
 
 	// Response Data
-	ResChannelNumber uint8
-	AuthTypeSupport  uint8
-	AuthStatus       uint8
-	// Other fields are omitted because it is not used
+	ResChannelNumber       uint8
+	AuthTypeSupport        uint8 // bit 7 IPMI v2.0+ extended capabilities, bits 5:0 supported auth types
+	AuthStatus             uint8
+	KGNonZero              bool // false: KG is set to default (all zeros)
+	PerMessageAuthDisabled bool
+	UserLevelAuthDisabled  bool
+	NonNullUsernames       bool
+	NullUsernames          bool
+	AnonymousLogin         bool
+	SupportIPMIV1_5        bool
+	SupportIPMIV2_0        bool
+	OEMID                  uint32
+	OEMAuxiliaryData       uint8
 }
 
-func (c *channelAuthCapCommand) Name() string           { return "Get Channel Authentication Capabilities" }
-func (c *channelAuthCapCommand) Code() uint8            { return 0x38 }
-func (c *channelAuthCapCommand) NetFnRsLUN() NetFnRsLUN { return NewNetFnRsLUN(NetFnAppReq, 0) }
-func (c *channelAuthCapCommand) String() string         { return cmdToJSON(c) }
+func (c *GetChannelAuthCapabilitiesCommand) Name() string {
+	return "Get Channel Authentication Capabilities"
+}
+func (c *GetChannelAuthCapabilitiesCommand) Code() uint8 { return 0x38 }
+
+func (c *GetChannelAuthCapabilitiesCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnAppReq, 0)
+}
 
-func (c *channelAuthCapCommand) Marshal() ([]byte, error) {
+func (c *GetChannelAuthCapabilitiesCommand) String() string { return cmdToJSON(c) }
+
+func (c *GetChannelAuthCapabilitiesCommand) Marshal() ([]byte, error) {
 	return []byte{c.ReqChannelNumber, byte(c.PrivilegeLevel)}, nil
 }
 
-func (c *channelAuthCapCommand) Unmarshal(buf []byte) ([]byte, error) {
+func (c *GetChannelAuthCapabilitiesCommand) Unmarshal(buf []byte) ([]byte, error) {
 	if err := cmdValidateLength(c, buf, 8); err != nil {
 		return nil, err
 	}
 	c.ResChannelNumber = buf[0]
 	c.AuthTypeSupport = buf[1]
 	c.AuthStatus = buf[2]
+	c.KGNonZero = buf[2]&0x20 != 0
+	c.PerMessageAuthDisabled = buf[2]&0x10 != 0
+	c.UserLevelAuthDisabled = buf[2]&0x08 != 0
+	c.NonNullUsernames = buf[2]&0x04 != 0
+	c.NullUsernames = buf[2]&0x02 != 0
+	c.AnonymousLogin = buf[2]&0x01 != 0
+	c.SupportIPMIV1_5 = buf[3]&0x01 != 0
+	c.SupportIPMIV2_0 = buf[3]&0x02 != 0
+	c.OEMID = uint32(buf[4]) | uint32(buf[5])<<8 | uint32(buf[6])<<16
+	c.OEMAuxiliaryData = buf[7]
 	return buf[8:], nil
 }
 
-func (c *channelAuthCapCommand) IsSupportedAuthType(t authType) bool {
+func (c *GetChannelAuthCapabilitiesCommand) IsSupportedAuthType(t authType) bool {
 	if t == authTypeRMCPPlus {
 		return (c.AuthTypeSupport & 0x80) != 0
 	} else {
@@ -47,13 +73,24 @@ func (c *channelAuthCapCommand) IsSupportedAuthType(t authType) bool {
 	}
 }
 
-func newChannelAuthCapCommand(v Version, l PrivilegeLevel) *channelAuthCapCommand {
+// SupportedAuthTypes Returns names of the IPMI v1.5 authentication types enabled on the channel.
+func (c *GetChannelAuthCapabilitiesCommand) SupportedAuthTypes() []string {
+	var types []string
+	for _, t := range []authType{authTypeNone, authTypeMD2, authTypeMD5, authTypePassword, authTypeOEM} {
+		if c.IsSupportedAuthType(t) {
+			types = append(types, t.String())
+		}
+	}
+	return types
+}
+
+func newChannelAuthCapCommand(v Version, l PrivilegeLevel) *GetChannelAuthCapabilitiesCommand {
 	var n uint8 = 0x0e // Retrieve information for channel
 	if v == V2_0 {
 		n |= 0x80 // For RMCP+
 	}
 
-	return &channelAuthCapCommand{
+	return &GetChannelAuthCapabilitiesCommand{
 		ReqChannelNumber: n,
 		PrivilegeLevel:   l,
 	}
@@ -89,28 +126,32 @@ func newSetSessionPrivilegeCommand(l PrivilegeLevel) *setSessionPrivilegeCommand
 	return &setSessionPrivilegeCommand{RequestedLevel: l}
 }
 
-// Close Session Command (Section 22.19)
-type closeSessionCommand struct {
+// CloseSessionCommand Close Session Command (Section 22.19)
+type CloseSessionCommand struct {
 	// Request Data
-	SessionID uint32
+	SessionID     uint32 // Session ID to close (0x00000000: close by SessionHandle)
+	SessionHandle uint8  // Only used if SessionID is 0
 }
 
-func (c *closeSessionCommand) Name() string           { return "Close Session" }
-func (c *closeSessionCommand) Code() uint8            { return 0x3c }
-func (c *closeSessionCommand) NetFnRsLUN() NetFnRsLUN { return NewNetFnRsLUN(NetFnAppReq, 0) }
-func (c *closeSessionCommand) String() string         { return cmdToJSON(c) }
+func (c *CloseSessionCommand) Name() string           { return "Close Session" }
+func (c *CloseSessionCommand) Code() uint8            { return 0x3c }
+func (c *CloseSessionCommand) NetFnRsLUN() NetFnRsLUN { return NewNetFnRsLUN(NetFnAppReq, 0) }
+func (c *CloseSessionCommand) String() string         { return cmdToJSON(c) }
 
-func (c *closeSessionCommand) Marshal() ([]byte, error) {
+func (c *CloseSessionCommand) Marshal() ([]byte, error) {
 	id := c.SessionID
+	if id == 0 {
+		return []byte{0, 0, 0, 0, c.SessionHandle}, nil
+	}
 	return []byte{byte(id), byte(id >> 8), byte(id >> 16), byte(id >> 24)}, nil
 }
 
-func (c *closeSessionCommand) Unmarshal(buf []byte) ([]byte, error) {
+func (c *CloseSessionCommand) Unmarshal(buf []byte) ([]byte, error) {
 	return buf, nil
 }
 
-func newCloseSessionCommand(id uint32) *closeSessionCommand {
-	return &closeSessionCommand{SessionID: id}
+func newCloseSessionCommand(id uint32) *CloseSessionCommand {
+	return &CloseSessionCommand{SessionID: id}
 }
 
 // GetSessionInfoCommand Get Session Info Command (Section 22.20)
@@ -156,7 +197,7 @@ func (c *GetSessionInfoCommand) Marshal() ([]byte, error) {
 }
 
 func (c *GetSessionInfoCommand) Unmarshal(buf []byte) ([]byte, error) {
-	if l := len(buf); l != 3 && l < 18 {
+	if l := len(buf); l != 3 && l < 6 {
 		return nil, &MessageError{
 			Message: fmt.Sprintf("Invalid %s Response size : %d", c.Name(), l),
 			Detail:  hex.EncodeToString(buf),
@@ -176,11 +217,54 @@ func (c *GetSessionInfoCommand) Unmarshal(buf []byte) ([]byte, error) {
 	c.ChannelType = (buf[5] & 0xf0) >> 4
 	c.ChannelNumber = buf[5] & 0x0f
 
-	// Supports only the 802.3 LAN channel type
-	c.ConsoleIP = net.IPv4(buf[6], buf[7], buf[8], buf[9])
-	c.ConsoleMAC = make(net.HardwareAddr, 6)
-	copy(c.ConsoleMAC, buf[10:16])
-	c.ConsolePort = binary.BigEndian.Uint16(buf[16:18])
+	// 802.3 LAN channel type (IPv4 or IPv6 addressing), other channel types have no console address
+	switch l := len(buf); {
+	case l >= 30:
+		// IPv6 addressing
+		c.ConsoleIP = make(net.IP, net.IPv6len)
+		copy(c.ConsoleIP, buf[6:22])
+		c.ConsoleMAC = make(net.HardwareAddr, 6)
+		copy(c.ConsoleMAC, buf[22:28])
+		c.ConsolePort = binary.BigEndian.Uint16(buf[28:30])
+		return buf[30:], nil
+	case l >= 18:
+		c.ConsoleIP = net.IPv4(buf[6], buf[7], buf[8], buf[9])
+		c.ConsoleMAC = make(net.HardwareAddr, 6)
+		copy(c.ConsoleMAC, buf[10:16])
+		c.ConsolePort = binary.BigEndian.Uint16(buf[16:18])
+		return buf[18:], nil
+	}
+
+	return nil, nil
+}
 
-	return buf[18:], nil
+// IsActive Returns `true` if an active session corresponds to the requested index.
+func (c *GetSessionInfoCommand) IsActive() bool {
+	return c.SessionHandle != 0
+}
+
+// GetUserNameCommand Get User Name Command (Section 22.29)
+type GetUserNameCommand struct {
+	// Request Data
+	UserID uint8
+
+	// Response Data
+	UserName string
+}
+
+func (c *GetUserNameCommand) Name() string           { return "Get User Name" }
+func (c *GetUserNameCommand) Code() uint8            { return 0x46 }
+func (c *GetUserNameCommand) NetFnRsLUN() NetFnRsLUN { return NewNetFnRsLUN(NetFnAppReq, 0) }
+func (c *GetUserNameCommand) String() string         { return cmdToJSON(c) }
+
+func (c *GetUserNameCommand) Marshal() ([]byte, error) {
+	return []byte{c.UserID & 0x3f}, nil
+}
+
+func (c *GetUserNameCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, userNameMaxLength); err != nil {
+		return nil, err
+	}
+	c.UserName = strings.TrimRight(string(buf[:userNameMaxLength]), "\x00")
+	return buf[userNameMaxLength:], nil
 }
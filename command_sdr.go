@@ -7,11 +7,17 @@ import (
 // GetSDRRepositoryInfoCommand Get SDR Repository Info Command (Section 33.9)
 type GetSDRRepositoryInfoCommand struct {
 	// Response Data
-	SDRVersion  uint8 // (0x01: IPMIv1.0, 0x51: IPMIv1.5, 0x02: IPMIv2.0)
-	RecordCount uint16
-	// Other fields are omitted because it is not used
+	SDRVersion         uint8 // (0x01: IPMIv1.0, 0x51: IPMIv1.5, 0x02: IPMIv2.0)
+	RecordCount        uint16
+	FreeSpace          uint16 // Free space in bytes (0xffff: 64KB-2 or more, 0xfffe: 64KB-2)
+	MostRecentAddition Timestamp
+	MostRecentErase    Timestamp
+	OperationSupport   uint8
 }
 
+// IsOverflow Returns `true` if a record could not be added because of lack of space
+func (c *GetSDRRepositoryInfoCommand) IsOverflow() bool { return c.OperationSupport&0x80 != 0 }
+
 func (c *GetSDRRepositoryInfoCommand) Name() string { return "Get SDR Repository Info" }
 func (c *GetSDRRepositoryInfoCommand) Code() uint8  { return 0x20 }
 
@@ -28,6 +34,10 @@ func (c *GetSDRRepositoryInfoCommand) Unmarshal(buf []byte) ([]byte, error) {
 	}
 	c.SDRVersion = buf[0]
 	c.RecordCount = binary.LittleEndian.Uint16(buf[1:3])
+	c.FreeSpace = binary.LittleEndian.Uint16(buf[3:5])
+	c.MostRecentAddition.Value = binary.LittleEndian.Uint32(buf[5:9])
+	c.MostRecentErase.Value = binary.LittleEndian.Uint32(buf[9:13])
+	c.OperationSupport = buf[13]
 	return buf[14:], nil
 }
 
@@ -95,3 +105,98 @@ func (c *GetSDRCommand) Unmarshal(buf []byte) ([]byte, error) {
 		return buf[c.ReadBytes:], nil
 	}
 }
+
+// GetDeviceSDRInfoCommand Get Device SDR Info Command (Section 35.2)
+type GetDeviceSDRInfoCommand struct {
+	// Request Data
+	RsLUN    uint8
+	SDRCount bool // `true`: get SDR count, `false`: get sensor count
+
+	// Response Data
+	Count             uint8 // Number of sensors or SDRs in the LUN
+	DynamicPopulation bool
+	LUNHasSensors     [4]bool
+	PopulationChange  uint32 // Sensor population change indicator (only valid for dynamic population)
+}
+
+func (c *GetDeviceSDRInfoCommand) Name() string { return "Get Device SDR Info" }
+func (c *GetDeviceSDRInfoCommand) Code() uint8  { return 0x20 }
+
+func (c *GetDeviceSDRInfoCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *GetDeviceSDRInfoCommand) String() string { return cmdToJSON(c) }
+
+func (c *GetDeviceSDRInfoCommand) Marshal() ([]byte, error) {
+	if c.SDRCount {
+		return []byte{0x01}, nil
+	}
+	return []byte{0x00}, nil
+}
+
+func (c *GetDeviceSDRInfoCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 2); err != nil {
+		return nil, err
+	}
+	c.Count = buf[0]
+	c.DynamicPopulation = buf[1]&0x80 != 0
+	for i := range c.LUNHasSensors {
+		c.LUNHasSensors[i] = buf[1]&(1<<uint(i)) != 0
+	}
+	if !c.DynamicPopulation || len(buf) < 6 {
+		return buf[2:], nil
+	}
+	c.PopulationChange = binary.LittleEndian.Uint32(buf[2:6])
+	return buf[6:], nil
+}
+
+// ReserveDeviceSDRRepositoryCommand Reserve Device SDR Repository Command (Section 35.4)
+type ReserveDeviceSDRRepositoryCommand struct {
+	// Request Data
+	RsLUN uint8
+
+	// Response Data
+	ReservationID uint16
+}
+
+func (c *ReserveDeviceSDRRepositoryCommand) Name() string { return "Reserve Device SDR Repository" }
+func (c *ReserveDeviceSDRRepositoryCommand) Code() uint8  { return 0x22 }
+
+func (c *ReserveDeviceSDRRepositoryCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *ReserveDeviceSDRRepositoryCommand) String() string           { return cmdToJSON(c) }
+func (c *ReserveDeviceSDRRepositoryCommand) Marshal() ([]byte, error) { return []byte{}, nil }
+
+func (c *ReserveDeviceSDRRepositoryCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 2); err != nil {
+		return nil, err
+	}
+	c.ReservationID = binary.LittleEndian.Uint16(buf)
+	return buf[2:], nil
+}
+
+// GetDeviceSDRCommand Get Device SDR Command (Section 35.3)
+type GetDeviceSDRCommand struct {
+	// Request Data
+	RsLUN uint8
+	GetSDRCommand
+}
+
+func (c *GetDeviceSDRCommand) Name() string { return "Get Device SDR" }
+func (c *GetDeviceSDRCommand) Code() uint8  { return 0x21 }
+
+func (c *GetDeviceSDRCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *GetDeviceSDRCommand) String() string { return cmdToJSON(c) }
+
+func (c *GetDeviceSDRCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 2); err != nil {
+		return nil, err
+	}
+	return c.GetSDRCommand.Unmarshal(buf)
+}
@@ -1,5 +1,11 @@
 package ipmigo
 
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
 // GetSensorReadingCommand Get Sensor Reading Command (Section 35.14)
 type GetSensorReadingCommand struct {
 	// Request Data
@@ -54,3 +60,420 @@ func (c *GetSensorReadingCommand) IsValid() bool {
 func (c *GetSensorReadingCommand) ThresholdStatus() ThresholdStatus {
 	return NewThresholdStatus(c.SensorData2)
 }
+
+// States Returns the state bits of discrete sensor (offsets 0-14).
+func (c *GetSensorReadingCommand) States() EventMask {
+	return EventMask(uint16(c.SensorData2)|uint16(c.SensorData3)<<8) & eventMaskDiscrete
+}
+
+// DiscreteStates Returns the asserted states of discrete sensor named by the sensor type
+// and the event/reading type of its SDR. Returns nil if sensor is threshold-base.
+func (c *GetSensorReadingCommand) DiscreteStates(sensorType SensorType, eventType EventType) SensorStates {
+	if eventType.IsThreshold() {
+		return nil
+	}
+
+	states := SensorStates{}
+	for _, offset := range c.States().Offsets() {
+		name, ok := EventDescription(eventType, sensorType, offset)
+		if !ok {
+			name = fmt.Sprintf("State %d", offset)
+		}
+		states = append(states, SensorState{Offset: offset, Name: name})
+	}
+	return states
+}
+
+// SensorState Asserted state of discrete sensor
+type SensorState struct {
+	Offset uint8
+	Name   string
+}
+
+// SensorStates Asserted states of discrete sensor
+type SensorStates []SensorState
+
+func (s SensorStates) String() string {
+	names := make([]string, len(s))
+	for i, state := range s {
+		names[i] = state.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// GetSensorThresholdsCommand Get Sensor Thresholds Command (Section 35.9)
+type GetSensorThresholdsCommand struct {
+	// Request Data
+	RsLUN        uint8
+	SensorNumber uint8
+
+	// Response Data
+	ReadableMask        ThresholdMask
+	LowerNonCritical    uint8
+	LowerCritical       uint8
+	LowerNonRecoverable uint8
+	UpperNonCritical    uint8
+	UpperCritical       uint8
+	UpperNonRecoverable uint8
+}
+
+func (c *GetSensorThresholdsCommand) Name() string { return "Get Sensor Thresholds" }
+func (c *GetSensorThresholdsCommand) Code() uint8  { return 0x27 }
+
+func (c *GetSensorThresholdsCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *GetSensorThresholdsCommand) String() string           { return cmdToJSON(c) }
+func (c *GetSensorThresholdsCommand) Marshal() ([]byte, error) { return []byte{c.SensorNumber}, nil }
+
+func (c *GetSensorThresholdsCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 7); err != nil {
+		return nil, err
+	}
+	c.ReadableMask = ThresholdMask(buf[0] & 0x3f)
+	c.LowerNonCritical = buf[1]
+	c.LowerCritical = buf[2]
+	c.LowerNonRecoverable = buf[3]
+	c.UpperNonCritical = buf[4]
+	c.UpperCritical = buf[5]
+	c.UpperNonRecoverable = buf[6]
+	return buf[7:], nil
+}
+
+// Threshold Returns the raw value of a single threshold and `true` if it is readable.
+func (c *GetSensorThresholdsCommand) Threshold(m ThresholdMask) (uint8, bool) {
+	switch m {
+	case ThresholdMaskLNC:
+		return c.LowerNonCritical, c.ReadableMask.Has(m)
+	case ThresholdMaskLCR:
+		return c.LowerCritical, c.ReadableMask.Has(m)
+	case ThresholdMaskLNR:
+		return c.LowerNonRecoverable, c.ReadableMask.Has(m)
+	case ThresholdMaskUNC:
+		return c.UpperNonCritical, c.ReadableMask.Has(m)
+	case ThresholdMaskUCR:
+		return c.UpperCritical, c.ReadableMask.Has(m)
+	case ThresholdMaskUNR:
+		return c.UpperNonRecoverable, c.ReadableMask.Has(m)
+	default:
+		return 0, false
+	}
+}
+
+// SetSensorThresholdsCommand Set Sensor Thresholds Command (Section 35.8)
+type SetSensorThresholdsCommand struct {
+	// Request Data
+	RsLUN               uint8
+	SensorNumber        uint8
+	SetMask             ThresholdMask // Thresholds to be set, others are ignored by BMC
+	LowerNonCritical    uint8
+	LowerCritical       uint8
+	LowerNonRecoverable uint8
+	UpperNonCritical    uint8
+	UpperCritical       uint8
+	UpperNonRecoverable uint8
+}
+
+func (c *SetSensorThresholdsCommand) Name() string { return "Set Sensor Thresholds" }
+func (c *SetSensorThresholdsCommand) Code() uint8  { return 0x26 }
+
+func (c *SetSensorThresholdsCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *SetSensorThresholdsCommand) String() string { return cmdToJSON(c) }
+
+func (c *SetSensorThresholdsCommand) Marshal() ([]byte, error) {
+	return []byte{c.SensorNumber, uint8(c.SetMask) & 0x3f,
+		c.LowerNonCritical, c.LowerCritical, c.LowerNonRecoverable,
+		c.UpperNonCritical, c.UpperCritical, c.UpperNonRecoverable}, nil
+}
+
+func (c *SetSensorThresholdsCommand) Unmarshal(buf []byte) ([]byte, error) {
+	return buf, nil
+}
+
+// SetThreshold Sets the raw value of a single threshold and marks it in SetMask.
+func (c *SetSensorThresholdsCommand) SetThreshold(m ThresholdMask, value uint8) {
+	switch m {
+	case ThresholdMaskLNC:
+		c.LowerNonCritical = value
+	case ThresholdMaskLCR:
+		c.LowerCritical = value
+	case ThresholdMaskLNR:
+		c.LowerNonRecoverable = value
+	case ThresholdMaskUNC:
+		c.UpperNonCritical = value
+	case ThresholdMaskUCR:
+		c.UpperCritical = value
+	case ThresholdMaskUNR:
+		c.UpperNonRecoverable = value
+	default:
+		return
+	}
+	c.SetMask |= m
+}
+
+// GetSensorReadingFactorsCommand Get Sensor Reading Factors Command (Section 35.5)
+type GetSensorReadingFactorsCommand struct {
+	// Request Data
+	RsLUN        uint8
+	SensorNumber uint8
+	Reading      uint8 // Reading byte the factors are returned for
+
+	// Response Data
+	NextReading uint8 // Next reading byte with different factors
+	Factors     SensorFactors
+}
+
+func (c *GetSensorReadingFactorsCommand) Name() string { return "Get Sensor Reading Factors" }
+func (c *GetSensorReadingFactorsCommand) Code() uint8  { return 0x23 }
+
+func (c *GetSensorReadingFactorsCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *GetSensorReadingFactorsCommand) String() string { return cmdToJSON(c) }
+
+func (c *GetSensorReadingFactorsCommand) Marshal() ([]byte, error) {
+	return []byte{c.SensorNumber, c.Reading}, nil
+}
+
+func (c *GetSensorReadingFactorsCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 1+sensorFactorsSize); err != nil {
+		return nil, err
+	}
+	c.NextReading = buf[0]
+	return c.Factors.Unmarshal(buf[1:])
+}
+
+// SetSensorHysteresisCommand Set Sensor Hysteresis Command (Section 35.6)
+type SetSensorHysteresisCommand struct {
+	// Request Data
+	RsLUN              uint8
+	SensorNumber       uint8
+	PositiveHysteresis uint8 // Raw value, 0 means no hysteresis
+	NegativeHysteresis uint8 // Raw value, 0 means no hysteresis
+}
+
+func (c *SetSensorHysteresisCommand) Name() string { return "Set Sensor Hysteresis" }
+func (c *SetSensorHysteresisCommand) Code() uint8  { return 0x24 }
+
+func (c *SetSensorHysteresisCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *SetSensorHysteresisCommand) String() string { return cmdToJSON(c) }
+
+func (c *SetSensorHysteresisCommand) Marshal() ([]byte, error) {
+	// Hysteresis mask is reserved, must be 0xff
+	return []byte{c.SensorNumber, 0xff, c.PositiveHysteresis, c.NegativeHysteresis}, nil
+}
+
+func (c *SetSensorHysteresisCommand) Unmarshal(buf []byte) ([]byte, error) {
+	return buf, nil
+}
+
+// GetSensorHysteresisCommand Get Sensor Hysteresis Command (Section 35.7)
+type GetSensorHysteresisCommand struct {
+	// Request Data
+	RsLUN        uint8
+	SensorNumber uint8
+
+	// Response Data
+	PositiveHysteresis uint8
+	NegativeHysteresis uint8
+}
+
+func (c *GetSensorHysteresisCommand) Name() string { return "Get Sensor Hysteresis" }
+func (c *GetSensorHysteresisCommand) Code() uint8  { return 0x25 }
+
+func (c *GetSensorHysteresisCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *GetSensorHysteresisCommand) String() string { return cmdToJSON(c) }
+
+func (c *GetSensorHysteresisCommand) Marshal() ([]byte, error) {
+	return []byte{c.SensorNumber, 0xff}, nil
+}
+
+func (c *GetSensorHysteresisCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 2); err != nil {
+		return nil, err
+	}
+	c.PositiveHysteresis = buf[0]
+	c.NegativeHysteresis = buf[1]
+	return buf[2:], nil
+}
+
+// SensorEventEnableAction Action on the selected events of Set Sensor Event Enable Command
+type SensorEventEnableAction uint8
+
+const (
+	SensorEventEnableNoChange  SensorEventEnableAction = 0x00 // Do not change individual enables
+	SensorEventEnableSelected  SensorEventEnableAction = 0x01 // Enable selected event messages
+	SensorEventDisableSelected SensorEventEnableAction = 0x02 // Disable selected event messages
+)
+
+// SetSensorEventEnableCommand Set Sensor Event Enable Command (Section 35.10)
+// The zero value turns the sensor off (disables both scanning and event messages),
+// set ScanningEnabled and EventMessagesEnabled from GetSensorEventEnableCommand to keep them.
+type SetSensorEventEnableCommand struct {
+	// Request Data
+	RsLUN                uint8
+	SensorNumber         uint8
+	EventMessagesEnabled bool // `false` disables all event messages from the sensor
+	ScanningEnabled      bool // `false` disables sensor scanning (the reading becomes unavailable)
+	Action               SensorEventEnableAction
+	AssertionMask        EventMask // Selected assertion events, ignored if Action is NoChange
+	DeassertionMask      EventMask // Selected deassertion events, ignored if Action is NoChange
+}
+
+func (c *SetSensorEventEnableCommand) Name() string { return "Set Sensor Event Enable" }
+func (c *SetSensorEventEnableCommand) Code() uint8  { return 0x28 }
+
+func (c *SetSensorEventEnableCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *SetSensorEventEnableCommand) String() string { return cmdToJSON(c) }
+
+func (c *SetSensorEventEnableCommand) Marshal() ([]byte, error) {
+	flags := uint8(c.Action&0x03) << 4
+	if c.EventMessagesEnabled {
+		flags |= 0x80
+	}
+	if c.ScanningEnabled {
+		flags |= 0x40
+	}
+
+	cmd := []byte{c.SensorNumber, flags}
+	if c.Action != SensorEventEnableNoChange {
+		cmd = append(cmd, c.AssertionMask.marshal()...)
+		cmd = append(cmd, c.DeassertionMask.marshal()...)
+	}
+	return cmd, nil
+}
+
+func (c *SetSensorEventEnableCommand) Unmarshal(buf []byte) ([]byte, error) {
+	return buf, nil
+}
+
+// GetSensorEventEnableCommand Get Sensor Event Enable Command (Section 35.11)
+type GetSensorEventEnableCommand struct {
+	// Request Data
+	RsLUN        uint8
+	SensorNumber uint8
+
+	// Response Data
+	EventMessagesEnabled bool
+	ScanningEnabled      bool
+	AssertionMask        EventMask // Enabled assertion events
+	DeassertionMask      EventMask // Enabled deassertion events
+}
+
+func (c *GetSensorEventEnableCommand) Name() string { return "Get Sensor Event Enable" }
+func (c *GetSensorEventEnableCommand) Code() uint8  { return 0x29 }
+
+func (c *GetSensorEventEnableCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *GetSensorEventEnableCommand) String() string           { return cmdToJSON(c) }
+func (c *GetSensorEventEnableCommand) Marshal() ([]byte, error) { return []byte{c.SensorNumber}, nil }
+
+func (c *GetSensorEventEnableCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 1); err != nil {
+		return nil, err
+	}
+	c.EventMessagesEnabled = buf[0]&0x80 != 0
+	c.ScanningEnabled = buf[0]&0x40 != 0
+	c.AssertionMask, c.DeassertionMask = unmarshalEventMasks(buf[1:])
+	return nil, nil
+}
+
+// RearmSensorEventsCommand Re-arm Sensor Events Command (Section 35.12)
+type RearmSensorEventsCommand struct {
+	// Request Data
+	RsLUN           uint8
+	SensorNumber    uint8
+	AssertionMask   EventMask // Both masks zero re-arms all events
+	DeassertionMask EventMask
+}
+
+func (c *RearmSensorEventsCommand) Name() string { return "Re-arm Sensor Events" }
+func (c *RearmSensorEventsCommand) Code() uint8  { return 0x2a }
+
+func (c *RearmSensorEventsCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *RearmSensorEventsCommand) String() string { return cmdToJSON(c) }
+
+func (c *RearmSensorEventsCommand) Marshal() ([]byte, error) {
+	if c.AssertionMask == 0 && c.DeassertionMask == 0 {
+		return []byte{c.SensorNumber, 0x00}, nil
+	}
+	cmd := []byte{c.SensorNumber, 0x80}
+	cmd = append(cmd, c.AssertionMask.marshal()...)
+	cmd = append(cmd, c.DeassertionMask.marshal()...)
+	return cmd, nil
+}
+
+func (c *RearmSensorEventsCommand) Unmarshal(buf []byte) ([]byte, error) {
+	return buf, nil
+}
+
+// GetSensorEventStatusCommand Get Sensor Event Status Command (Section 35.13)
+type GetSensorEventStatusCommand struct {
+	// Request Data
+	RsLUN        uint8
+	SensorNumber uint8
+
+	// Response Data
+	EventDisabled      bool
+	ScanningDisabled   bool
+	ReadingUnavailable bool
+	AssertionMask      EventMask // Asserted events
+	DeassertionMask    EventMask // Deasserted events
+}
+
+func (c *GetSensorEventStatusCommand) Name() string { return "Get Sensor Event Status" }
+func (c *GetSensorEventStatusCommand) Code() uint8  { return 0x2b }
+
+func (c *GetSensorEventStatusCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnSensorReq, c.RsLUN)
+}
+
+func (c *GetSensorEventStatusCommand) String() string           { return cmdToJSON(c) }
+func (c *GetSensorEventStatusCommand) Marshal() ([]byte, error) { return []byte{c.SensorNumber}, nil }
+
+func (c *GetSensorEventStatusCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 1); err != nil {
+		return nil, err
+	}
+	c.EventDisabled = buf[0]&0x80 == 0
+	c.ScanningDisabled = buf[0]&0x40 == 0
+	c.ReadingUnavailable = buf[0]&0x20 != 0
+	c.AssertionMask, c.DeassertionMask = unmarshalEventMasks(buf[1:])
+	return nil, nil
+}
+
+// unmarshalEventMasks Decodes optional assertion and deassertion masks
+func unmarshalEventMasks(buf []byte) (assertion, deassertion EventMask) {
+	switch l := len(buf); {
+	case l == 1:
+		assertion = EventMask(buf[0])
+	case l == 2, l == 3:
+		assertion = EventMask(binary.LittleEndian.Uint16(buf))
+		if l == 3 {
+			deassertion = EventMask(buf[2])
+		}
+	case l >= 4:
+		assertion = EventMask(binary.LittleEndian.Uint16(buf))
+		deassertion = EventMask(binary.LittleEndian.Uint16(buf[2:]))
+	}
+	return assertion & eventMaskDiscrete, deassertion & eventMaskDiscrete
+}
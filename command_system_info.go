@@ -0,0 +1,109 @@
+package ipmigo
+
+import (
+	"fmt"
+)
+
+// SystemInfoParameter System Info Parameter selector (Table 22-16a)
+type SystemInfoParameter uint8
+
+const (
+	SystemInfoParamSetInProgress   SystemInfoParameter = 0x00
+	SystemInfoParamFirmwareVersion SystemInfoParameter = 0x01
+	SystemInfoParamSystemName      SystemInfoParameter = 0x02
+	SystemInfoParamPrimaryOSName   SystemInfoParameter = 0x03
+	SystemInfoParamOSName          SystemInfoParameter = 0x04
+	SystemInfoParamOSVersion       SystemInfoParameter = 0x05
+	SystemInfoParamBMCURL          SystemInfoParameter = 0x06
+	SystemInfoParamHypervisorURL   SystemInfoParameter = 0x07
+)
+
+func (p SystemInfoParameter) String() string {
+	switch p {
+	case SystemInfoParamSetInProgress:
+		return "Set In Progress"
+	case SystemInfoParamFirmwareVersion:
+		return "System Firmware Version"
+	case SystemInfoParamSystemName:
+		return "System Name"
+	case SystemInfoParamPrimaryOSName:
+		return "Primary Operating System Name"
+	case SystemInfoParamOSName:
+		return "Operating System Name"
+	case SystemInfoParamOSVersion:
+		return "Present OS Version Number"
+	case SystemInfoParamBMCURL:
+		return "BMC URL"
+	case SystemInfoParamHypervisorURL:
+		return "Base OS/Hypervisor URL"
+	default:
+		if p >= 0xc0 {
+			return fmt.Sprintf("OEM(%d)", uint8(p))
+		}
+		return fmt.Sprintf("Reserved(%d)", uint8(p))
+	}
+}
+
+// GetSystemInfoParametersCommand Get System Info Parameters Command (Section 22.14b)
+type GetSystemInfoParametersCommand struct {
+	// Request Data
+	RevisionOnly      bool // Get parameter revision only
+	ParameterSelector SystemInfoParameter
+	SetSelector       uint8
+	BlockSelector     uint8
+
+	// Response Data
+	ParameterRevision uint8
+	Data              []byte // Configuration parameter data, empty if RevisionOnly
+}
+
+func (c *GetSystemInfoParametersCommand) Name() string { return "Get System Info Parameters" }
+func (c *GetSystemInfoParametersCommand) Code() uint8  { return 0x59 }
+
+func (c *GetSystemInfoParametersCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnAppReq, 0)
+}
+
+func (c *GetSystemInfoParametersCommand) String() string { return cmdToJSON(c) }
+
+func (c *GetSystemInfoParametersCommand) Marshal() ([]byte, error) {
+	var b uint8
+	if c.RevisionOnly {
+		b = 0x80
+	}
+	return []byte{b, uint8(c.ParameterSelector), c.SetSelector, c.BlockSelector}, nil
+}
+
+func (c *GetSystemInfoParametersCommand) Unmarshal(buf []byte) ([]byte, error) {
+	if err := cmdValidateLength(c, buf, 1); err != nil {
+		return nil, err
+	}
+	c.ParameterRevision = buf[0]
+	c.Data = make([]byte, len(buf)-1)
+	copy(c.Data, buf[1:])
+	return nil, nil
+}
+
+// SetSystemInfoParametersCommand Set System Info Parameters Command (Section 22.14a)
+type SetSystemInfoParametersCommand struct {
+	// Request Data
+	ParameterSelector SystemInfoParameter
+	Data              []byte // Configuration parameter data
+}
+
+func (c *SetSystemInfoParametersCommand) Name() string { return "Set System Info Parameters" }
+func (c *SetSystemInfoParametersCommand) Code() uint8  { return 0x58 }
+
+func (c *SetSystemInfoParametersCommand) NetFnRsLUN() NetFnRsLUN {
+	return NewNetFnRsLUN(NetFnAppReq, 0)
+}
+
+func (c *SetSystemInfoParametersCommand) String() string { return cmdToJSON(c) }
+
+func (c *SetSystemInfoParametersCommand) Marshal() ([]byte, error) {
+	return append([]byte{uint8(c.ParameterSelector)}, c.Data...), nil
+}
+
+func (c *SetSystemInfoParametersCommand) Unmarshal(buf []byte) ([]byte, error) {
+	return buf, nil
+}
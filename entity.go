@@ -0,0 +1,238 @@
+package ipmigo
+
+import (
+	"fmt"
+)
+
+// EntityID Entity ID Code (Table 43-13)
+type EntityID uint8
+
+var entityIDDescriptions []string = []string{
+	"unspecified",
+	"other",
+	"unknown",
+	"processor",
+	"disk or disk bay",
+	"peripheral bay",
+	"system management module",
+	"system board",
+	"memory module",
+	"processor module",
+	"power supply",
+	"add-in card",
+	"front panel board",
+	"back panel board",
+	"power system board",
+	"drive backplane",
+	"system internal expansion board",
+	"other system board",
+	"processor board",
+	"power unit / power domain",
+	"power module / DC-to-DC converter",
+	"power management / power distribution board",
+	"chassis back panel board",
+	"system chassis",
+	"sub-chassis",
+	"other chassis board",
+	"disk drive bay",
+	"peripheral bay",
+	"device bay",
+	"fan / cooling device",
+	"cooling unit / cooling domain",
+	"cable / interconnect",
+	"memory device",
+	"system management software",
+	"system firmware",
+	"operating system",
+	"system bus",
+	"group",
+	"remote (out of band) management communication device",
+	"external environment",
+	"battery",
+	"processing blade",
+	"connectivity switch",
+	"processor/memory module",
+	"I/O module",
+	"processor / IO module",
+	"management controller firmware",
+	"IPMI channel",
+	"PCI bus",
+	"PCI Express bus",
+	"SCSI bus (parallel)",
+	"SATA / SAS bus",
+	"processor / front-side bus",
+	"real time clock (RTC)",
+	"reserved",
+	"air inlet",
+}
+
+func (e EntityID) String() string {
+	switch i := int(e); {
+	case i < len(entityIDDescriptions):
+		return entityIDDescriptions[i]
+	case i == 0x40:
+		return "air inlet"
+	case i == 0x41:
+		return "processor"
+	case i == 0x42:
+		return "baseboard"
+	case i >= 0x90 && i <= 0xaf:
+		return fmt.Sprintf("chassis-specific(%d)", i)
+	case i >= 0xb0 && i <= 0xcf:
+		return fmt.Sprintf("board-set specific(%d)", i)
+	case i >= 0xd0:
+		return fmt.Sprintf("OEM(%d)", i)
+	default:
+		return fmt.Sprintf("Reserved(%d)", i)
+	}
+}
+
+// Entity Physical or logical entity (Section 39)
+type Entity struct {
+	ID       EntityID
+	Instance uint8 // (0x00-0x5f: system-relative, 0x60-0x7f: device-relative)
+
+	// Management controller of device-relative instance (8-bit slave address form)
+	DeviceAddress uint8
+	Channel       uint8
+}
+
+// IsDeviceRelative Returns `true` if the instance is relative to the management controller.
+func (e Entity) IsDeviceRelative() bool { return e.Instance&0x7f >= 0x60 }
+
+func (e Entity) String() string {
+	if e.IsDeviceRelative() {
+		return fmt.Sprintf("%s %d (device 0x%02x)", e.ID, e.Instance&0x7f-0x60, e.DeviceAddress)
+	}
+	return fmt.Sprintf("%s %d", e.ID, e.Instance&0x7f)
+}
+
+// key Returns the entity identity, device-relative instances are unique per controller
+func (e Entity) key() Entity {
+	k := Entity{ID: e.ID, Instance: e.Instance & 0x7f}
+	if k.IsDeviceRelative() {
+		k.DeviceAddress = e.DeviceAddress
+		k.Channel = e.Channel
+	}
+	return k
+}
+
+func containedEntities(ranges bool, contained []Entity) []Entity {
+	entities := []Entity{}
+	if !ranges {
+		for _, e := range contained {
+			if e.ID != 0 {
+				entities = append(entities, e)
+			}
+		}
+		return entities
+	}
+
+	for i := 0; i+1 < len(contained); i += 2 {
+		first, last := contained[i], contained[i+1]
+		if first.ID == 0 {
+			continue
+		}
+		for n := int(first.Instance); n <= int(last.Instance); n++ {
+			e := first
+			e.Instance = uint8(n)
+			entities = append(entities, e)
+		}
+	}
+	return entities
+}
+
+// EntityNode Node of the entity tree
+type EntityNode struct {
+	Entity   Entity
+	Parent   *EntityNode `json:"-"`
+	Children []*EntityNode
+	Records  []SDR // Sensor and device locator records of the entity
+}
+
+// recordEntity Returns the entity of a sensor or device locator record
+func recordEntity(r SDR) (Entity, bool) {
+	switch s := r.(type) {
+	case *SDRFullSensor:
+		return Entity{ID: EntityID(s.Entity.ID), Instance: s.Entity.Instance, DeviceAddress: s.OwnerID & 0xfe,
+			Channel: s.ChannelNumber}, true
+	case *SDRCompactSensor:
+		return Entity{ID: EntityID(s.Entity.ID), Instance: s.Entity.Instance, DeviceAddress: s.OwnerID & 0xfe,
+			Channel: s.ChannelNumber}, true
+	case *SDREventOnlySensor:
+		return Entity{ID: EntityID(s.Entity.ID), Instance: s.Entity.Instance, DeviceAddress: s.OwnerID & 0xfe,
+			Channel: s.ChannelNumber}, true
+	case *SDRFRUDeviceLocator:
+		return Entity{ID: EntityID(s.Entity.ID), Instance: s.Entity.Instance, DeviceAddress: s.SlaveAddress << 1,
+			Channel: s.ChannelNumber}, true
+	case *SDRGenericDeviceLocator:
+		return Entity{ID: EntityID(s.Entity.ID), Instance: s.Entity.Instance, DeviceAddress: s.AccessAddress << 1,
+			Channel: s.ChannelNumber}, true
+	case *SDRMCDeviceLocator:
+		return Entity{ID: EntityID(s.Entity.ID), Instance: s.Entity.Instance, DeviceAddress: s.SlaveAddress << 1,
+			Channel: s.ChannelNumber}, true
+	default:
+		return Entity{}, false
+	}
+}
+
+// SDRBuildEntityTree Returns the root entities of the tree built from entity association records,
+// with sensor and device locator records attached to their entities.
+func SDRBuildEntityTree(records []SDR) []*EntityNode {
+	nodes := make(map[Entity]*EntityNode)
+	order := []*EntityNode{}
+	node := func(e Entity) *EntityNode {
+		k := e.key()
+		n, ok := nodes[k]
+		if !ok {
+			n = &EntityNode{Entity: k}
+			nodes[k] = n
+			order = append(order, n)
+		}
+		return n
+	}
+	isAncestor := func(a, n *EntityNode) bool {
+		for p := n; p != nil; p = p.Parent {
+			if p == a {
+				return true
+			}
+		}
+		return false
+	}
+	associate := func(container Entity, contained []Entity) {
+		parent := node(container)
+		for _, e := range contained {
+			child := node(e)
+			// An entity has only one container, ignore loops
+			if child.Parent != nil || isAncestor(child, parent) {
+				continue
+			}
+			child.Parent = parent
+			parent.Children = append(parent.Children, child)
+		}
+	}
+
+	for _, r := range records {
+		switch a := r.(type) {
+		case *SDREntityAssociation:
+			associate(a.Container, a.Entities())
+		case *SDRDeviceEntityAssociation:
+			associate(a.Container, a.Entities())
+		}
+	}
+
+	for _, r := range records {
+		if e, ok := recordEntity(r); ok {
+			n := node(e)
+			n.Records = append(n.Records, r)
+		}
+	}
+
+	roots := []*EntityNode{}
+	for _, n := range order {
+		if n.Parent == nil {
+			roots = append(roots, n)
+		}
+	}
+	return roots
+}
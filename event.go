@@ -9,6 +9,34 @@ func (e EventType) IsGeneric() bool        { return e >= 0x02 && e <= 0x0c }
 func (e EventType) IsSensorSpecific() bool { return e == 0x6f }
 func (e EventType) IsOEM() bool            { return e >= 0x70 && e <= 0x7f }
 
+// EventDescription Returns the description of the event/state offset (Table 42-2, 42-3).
+func EventDescription(t EventType, sensorType SensorType, offset uint8) (string, bool) {
+	return eventDescription(t, sensorType, offset, 0xff, 0xff)
+}
+
+// eventDescription Returns the description of the event/state offset,
+// d2 and d3 of 0xff mean unspecified event data.
+func eventDescription(t EventType, sensorType SensorType, offset, d2, d3 uint8) (string, bool) {
+	switch {
+	case t.IsGeneric() || t.IsThreshold():
+		desc, ok := sensorGenericEventDesc[uint32(t)<<8|uint32(offset)]
+		return desc, ok
+	case t.IsSensorSpecific():
+		for {
+			// First, try to get a more detailed definition
+			desc, ok := sensorSpecificEventDesc[uint32(sensorType)<<24|uint32(offset)<<16|uint32(d2)<<8|uint32(d3)]
+			if !ok && (d2 != 0xff || d3 != 0xff) {
+				// If not found, get a general definition
+				d2, d3 = 0xff, 0xff
+				continue
+			}
+			return desc, ok
+		}
+	default:
+		return "", false
+	}
+}
+
 // Sensor generic event description (Table 42-2)
 var sensorGenericEventDesc = map[uint32]string{
 	// Event Type, Offset
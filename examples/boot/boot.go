@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/v-vydra/ipmigo"
+)
+
+// Set PXE boot for the next boot only and power cycle the chassis.
+func main() {
+	c, err := ipmigo.NewClient(ipmigo.Arguments{
+		Version:       ipmigo.V2_0,
+		Address:       "192.168.1.1:623",
+		Timeout:       2 * time.Second,
+		Retries:       1,
+		Username:      "myuser",
+		Password:      "mypass",
+		CipherSuiteID: 3,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err := c.Open(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer c.Close()
+
+	flags, err := ipmigo.BootGetFlags(c)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Current boot device : %s (valid: %t, persistent: %t, EFI: %t)\n",
+		flags.Device, flags.Valid, flags.Persistent, flags.EFI)
+
+	fmt.Printf("Setting PXE boot once ...\n")
+	if err := ipmigo.BootSetDevice(c, ipmigo.BootDevicePXE, false, true); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("Sending power CYCLE command ...\n")
+	cmdChassisControl := &ipmigo.SetChassisControlCommand{
+		ChassisControl: ipmigo.ChassisControlPowerCycle,
+	}
+	if err := c.Execute(cmdChassisControl); err != nil {
+		fmt.Printf("unable to set chassis control: %v", err)
+		return
+	}
+}
@@ -26,6 +26,13 @@ func Confirm(prompt string, args ...interface{}) bool {
 	}
 }
 
+func powerState(on bool) string {
+	if on {
+		return "ON"
+	}
+	return "OFF"
+}
+
 func main() {
 	c, err := ipmigo.NewClient(ipmigo.Arguments{
 		Version:       ipmigo.V2_0,
@@ -87,26 +94,21 @@ func main() {
 	fmt.Printf("Board product                : %s\n", boardProduct)
 	fmt.Printf("Board serial                 : %s\n", boardSerial)
 
-	fmt.Printf("Current chassis power status : ")
-	if cmdGetChassisStatus.PowerIsOn {
-		fmt.Printf(" ON\n")
-	} else {
-		fmt.Printf(" OFF\n")
-	}
+	fmt.Printf("Current chassis power status :  %s\n", powerState(cmdGetChassisStatus.PowerIsOn))
 
 	fmt.Printf("\n\n")
+	power := ipmigo.NewPowerController(c)
 	if cmdGetChassisStatus.PowerIsOn {
 		powerChange := Confirm("Power is ON, do you really want to power off? [YeS/n]")
 		if powerChange {
 			fmt.Printf("Sending power DOWN command ...\n")
 
-			cmdChassisControl := &ipmigo.SetChassisControlCommand{
-				ChassisControl: ipmigo.ChassisControlPowerDown,
-			}
-			if err := c.Execute(cmdChassisControl); err != nil {
+			status, err := power.Off()
+			if err != nil {
 				fmt.Printf("unable to set chassis control: %v", err)
 				return
 			}
+			fmt.Printf("Power is %s\n", powerState(status.PowerIsOn))
 		} else {
 			fmt.Printf("Nothing to do\n")
 		}
@@ -116,13 +118,12 @@ func main() {
 		if powerChange {
 			fmt.Printf("Sending power UP command ...\n")
 
-			cmdChassisControl := &ipmigo.SetChassisControlCommand{
-				ChassisControl: ipmigo.ChassisControlPowerUp,
-			}
-			if err := c.Execute(cmdChassisControl); err != nil {
+			status, err := power.On()
+			if err != nil {
 				fmt.Printf("unable to set chassis control: %v", err)
 				return
 			}
+			fmt.Printf("Power is %s\n", powerState(status.PowerIsOn))
 		} else {
 			fmt.Printf("Nothing to do\n")
 		}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/v-vydra/ipmigo"
+)
+
+// Watch threshold-base sensors and print status and reading changes.
+func main() {
+	c, err := ipmigo.NewClient(ipmigo.Arguments{
+		Version:       ipmigo.V2_0,
+		Address:       "192.168.1.1:623",
+		Timeout:       2 * time.Second,
+		Retries:       1,
+		Username:      "myuser",
+		Password:      "mypass",
+		CipherSuiteID: 3,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err := c.Open(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer c.Close()
+
+	records, err := ipmigo.SDRGetRecordsRepo(c, func(id uint16, t ipmigo.SDRType) bool {
+		return t == ipmigo.SDRTypeFullSensor
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	w := ipmigo.NewSensorWatcher(c, records)
+	w.Interval = 5 * time.Second
+	w.Debounce = 2
+	w.Hysteresis = 2.0
+	w.Delta = 5.0
+
+	events := w.Start()
+	defer w.Stop()
+
+	timeout := time.After(10 * time.Minute)
+	for {
+		select {
+		case e := <-events:
+			switch e.Kind {
+			case ipmigo.SensorEventError:
+				fmt.Printf("%s %s(0x%02x) error: %v\n", e.Time.Format(time.RFC3339), e.Name, e.Number, e.Err)
+			case ipmigo.SensorEventStatus:
+				fmt.Printf("%s %-16s status %s -> %s (%.2f %s)\n", e.Time.Format(time.RFC3339),
+					e.Sensor.Name, e.Previous.Status, e.Sensor.Status, e.Sensor.Value, e.Sensor.Unit)
+			default:
+				fmt.Printf("%s %-16s %s: %.2f -> %.2f %s\n", e.Time.Format(time.RFC3339),
+					e.Sensor.Name, e.Kind, e.Previous.Value, e.Sensor.Value, e.Sensor.Unit)
+			}
+		case <-timeout:
+			return
+		}
+	}
+}
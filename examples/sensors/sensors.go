@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/v-vydra/ipmigo"
+)
+
+// | Name | Type | Reading | Units | Status |
+var format = "| %-16s | %-30s | %-10s | %-20s | %-3s |\n"
+
+// Print all sensors with readings, threshold status and discrete states.
+func main() {
+	c, err := ipmigo.NewClient(ipmigo.Arguments{
+		Version:       ipmigo.V2_0,
+		Address:       "192.168.1.1:623",
+		Timeout:       2 * time.Second,
+		Retries:       1,
+		Username:      "myuser",
+		Password:      "mypass",
+		CipherSuiteID: 3,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err := c.Open(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer c.Close()
+
+	sensors, err := ipmigo.SensorGetReadings(c, nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, s := range sensors {
+		units, reading, status := "discrete", "n/a", "n/a"
+		if s.Available {
+			if s.Analog {
+				units = s.Unit
+				reading = fmt.Sprintf("%.2f", s.Value)
+			} else {
+				reading = fmt.Sprintf("0x%02x", s.RawReading)
+			}
+			if s.EventType.IsThreshold() {
+				status = string(s.Status)
+			} else if len(s.States) > 0 {
+				status = s.States.String()
+			}
+		}
+		fmt.Printf(format, s.Name, s.SensorType, reading, units, status)
+	}
+}
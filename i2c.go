@@ -0,0 +1,163 @@
+package ipmigo
+
+import (
+	"io"
+	"time"
+)
+
+const (
+	i2cDefaultReadChunk  = 32
+	i2cDefaultWriteChunk = 8 // smallest common EEPROM page size
+)
+
+// I2CDevice I2C device (e.g. EEPROM) accessed with Master Write-Read Command,
+// implements io.ReaderAt and io.WriterAt.
+type I2CDevice struct {
+	Client        *Client
+	BusType       I2CBusType
+	BusID         uint8
+	ChannelNumber uint8
+	SlaveAddress  uint8 // bits 7:1 Slave Address (7-bit), bit 0 - reserved
+
+	OffsetSize int           // Size of the offset (1 or 2 bytes, MSB first) written before each transfer
+	Size       int64         // Device size, 0 means the whole offset range
+	ReadChunk  int           // Max bytes read at one call (default 32)
+	WriteChunk int           // EEPROM page size, writes never cross its boundary (default 8, at most 255 bytes at one call)
+	WriteDelay time.Duration // Wait after each write (EEPROM write cycle)
+}
+
+// NewI2CDevice Create an I2C device with a 1 byte offset
+func NewI2CDevice(c *Client, busType I2CBusType, busID, channel, slaveAddress uint8) *I2CDevice {
+	return &I2CDevice{
+		Client:        c,
+		BusType:       busType,
+		BusID:         busID,
+		ChannelNumber: channel,
+		SlaveAddress:  slaveAddress,
+		OffsetSize:    1,
+		ReadChunk:     i2cDefaultReadChunk,
+		WriteChunk:    i2cDefaultWriteChunk,
+		WriteDelay:    5 * time.Millisecond,
+	}
+}
+
+func (d *I2CDevice) command(off int64, data []byte, readCount int) *MasterWriteReadCommand {
+	buf := make([]byte, 0, d.OffsetSize+len(data))
+	for i := d.OffsetSize - 1; i >= 0; i-- {
+		buf = append(buf, byte(off>>(8*uint(i))))
+	}
+	return &MasterWriteReadCommand{
+		BusType:       d.BusType,
+		BusID:         d.BusID,
+		ChannelNumber: d.ChannelNumber,
+		SlaveAddress:  d.SlaveAddress,
+		ReadCount:     uint8(readCount),
+		DataWrite:     append(buf, data...),
+	}
+}
+
+// limit Returns the number of bytes accessible from offset
+func (d *I2CDevice) limit(off int64, n int) (int, error) {
+	if off < 0 {
+		return 0, &ArgumentError{Value: off, Message: "Negative offset"}
+	}
+	if d.OffsetSize < 0 || d.OffsetSize > 4 {
+		return 0, &ArgumentError{Value: d.OffsetSize, Message: "Invalid I2C offset size"}
+	}
+
+	size := d.Size
+	if d.OffsetSize > 0 {
+		if max := int64(1) << (8 * uint(d.OffsetSize)); size <= 0 || size > max {
+			size = max
+		}
+	}
+	if size <= 0 {
+		// Device without offset and size
+		return n, nil
+	}
+	if off >= size {
+		return 0, io.EOF
+	}
+	if r := size - off; int64(n) > r {
+		return int(r), io.EOF
+	}
+	return n, nil
+}
+
+func chunkSize(n, def int) int {
+	if n <= 0 {
+		return def
+	}
+	if n > 255 {
+		return 255
+	}
+	return n
+}
+
+// ReadAt Reads len(p) bytes from the device starting at offset off.
+func (d *I2CDevice) ReadAt(p []byte, off int64) (int, error) {
+	total, eof := d.limit(off, len(p))
+	if total == 0 {
+		return 0, eof
+	}
+
+	chunk := chunkSize(d.ReadChunk, i2cDefaultReadChunk)
+	n := 0
+	for n < total {
+		size := total - n
+		if size > chunk {
+			size = chunk
+		}
+
+		cmd := d.command(off+int64(n), nil, size)
+		if err := d.Client.Execute(cmd); err != nil {
+			return n, err
+		}
+		n += copy(p[n:], cmd.Data)
+	}
+
+	return n, eof
+}
+
+// WriteAt Writes len(p) bytes to the device starting at offset off.
+func (d *I2CDevice) WriteAt(p []byte, off int64) (int, error) {
+	total, eof := d.limit(off, len(p))
+	if total == 0 {
+		return 0, eof
+	}
+	if eof != nil {
+		// Partial writes are not allowed by io.WriterAt
+		return 0, &ArgumentError{Value: off, Message: "Write exceeds I2C device size"}
+	}
+
+	page := d.WriteChunk
+	if page <= 0 {
+		page = i2cDefaultWriteChunk
+	}
+	// Max bytes of one transfer following the offset
+	max := 255 - d.OffsetSize
+
+	n := 0
+	for n < total {
+		pos := off + int64(n)
+		// Do not cross the page boundary
+		size := page - int(pos%int64(page))
+		if size > max {
+			size = max
+		}
+		if r := total - n; size > r {
+			size = r
+		}
+
+		if err := d.Client.Execute(d.command(pos, p[n:n+size], 0)); err != nil {
+			return n, err
+		}
+		n += size
+
+		if d.WriteDelay > 0 {
+			time.Sleep(d.WriteDelay)
+		}
+	}
+
+	return n, nil
+}
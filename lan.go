@@ -190,6 +190,11 @@ func (s *sessionV1_5) Close() error {
 	return nil
 }
 
+// Abandon Drops the session, nothing is sent to the BMC in IPMI v1.5
+func (s *sessionV1_5) Abandon() error {
+	return s.Close()
+}
+
 func (s *sessionV1_5) Execute(cmd Command) error {
 	if err := s.Open(); err != nil {
 		return err
@@ -238,6 +243,12 @@ func (s *sessionV1_5) execute(cmd Command) (response, error) {
 	if _, err = cmd.Unmarshal(rsm.Data); err != nil {
 		return nil, err
 	}
+	if b, ok := cmd.(*BridgedCommand); ok && b.pending {
+		return nil, &MessageError{
+			Message: "Bridged response in a separate message is not supported (IPMI v1.5)",
+			Detail:  res.String(),
+		}
+	}
 
 	return res, nil
 }
@@ -311,7 +311,14 @@ func (s *sessionV2_0) Close() error {
 		if err := s.Execute(newCloseSessionCommand(s.id)); err != nil {
 			return err
 		}
+	}
+
+	return s.Abandon()
+}
 
+// Abandon Drops the session without sending Close Session Command (e.g. the BMC has been reset)
+func (s *sessionV2_0) Abandon() error {
+	if s.ActiveSession() {
 		s.id = 0
 		s.sequence = 0
 		s.rqSeq = 0
@@ -320,10 +327,10 @@ func (s *sessionV2_0) Close() error {
 	}
 
 	if c := s.conn; c != nil {
+		s.conn = nil
 		if err := c.Close(); err != nil {
 			return err
 		}
-		s.conn = nil
 	}
 
 	return nil
@@ -378,6 +385,22 @@ func (s *sessionV2_0) execute(cmd Command) (response, error) {
 		return nil, err
 	}
 
+	// The bridged response is received in a separate message (Section 6.12.8)
+	if b, ok := cmd.(*BridgedCommand); ok && b.pending {
+		if res, err = s.ReceivePacket(); err != nil {
+			return nil, err
+		}
+		if rsm, ok = res.Response.(*ipmiResponseMessage); !ok {
+			return nil, &MessageError{
+				Message: "Received an unexpected message (Bridged Command)",
+				Detail:  res.String(),
+			}
+		}
+		if err = b.unmarshalResponse(rsm.CompletionCode, rsm.Data); err != nil {
+			return nil, err
+		}
+	}
+
 	return res, nil
 }
 
@@ -439,6 +462,19 @@ func (s *sessionV2_0) SendPacket(req *ipmiPacket) (*ipmiPacket, error) {
 	if err != nil {
 		return nil, err
 	}
+	return s.readPacket(res, msg)
+}
+
+// ReceivePacket Receives a packet without sending a request (e.g. response of a bridged request)
+func (s *sessionV2_0) ReceivePacket() (*ipmiPacket, error) {
+	res, msg, err := recvMessage(s.conn, s.args.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return s.readPacket(res, msg)
+}
+
+func (s *sessionV2_0) readPacket(res response, msg []byte) (*ipmiPacket, error) {
 	pkt, ok := res.(*ipmiPacket)
 	if !ok {
 		return nil, &MessageError{
@@ -0,0 +1,64 @@
+package ipmigo
+
+// ManagementController Management controller found on IPMB
+type ManagementController struct {
+	Address uint8 // Slave address (8-bit form)
+	Channel uint8
+	Name    string
+
+	Locator  *SDRMCDeviceLocator // nil if the controller has no locator record (BMC)
+	DeviceID *GetDeviceIDCommand // nil if the controller did not respond
+	Err      error               // Error of Get Device ID
+}
+
+func (m *ManagementController) String() string { return toJSON(m) }
+
+// MCDiscover Returns the BMC and all management controllers of MC Device Locator records
+// with their capabilities from Get Device ID Command bridged to each controller.
+func MCDiscover(c *Client) ([]*ManagementController, error) {
+	records, err := SDRGetRecordsRepo(c, func(id uint16, t SDRType) bool {
+		return t == SDRTypeMCDeviceLocator
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bmc := &ManagementController{Address: bmcSlaveAddress, Name: "BMC"}
+	mcs := []*ManagementController{bmc}
+	for _, r := range records {
+		l, ok := r.(*SDRMCDeviceLocator)
+		if !ok {
+			continue
+		}
+
+		addr := l.SlaveAddress << 1
+		if addr == bmcSlaveAddress && l.ChannelNumber == 0 {
+			bmc.Locator = l
+			if name := l.SensorID(); name != "" {
+				bmc.Name = name
+			}
+			continue
+		}
+		mcs = append(mcs, &ManagementController{
+			Address: addr,
+			Channel: l.ChannelNumber,
+			Name:    l.SensorID(),
+			Locator: l,
+		})
+	}
+
+	for _, mc := range mcs {
+		gdi := &GetDeviceIDCommand{}
+		if err := c.Execute(NewBridgedCommand(mc.Channel, mc.Address, gdi)); err != nil {
+			if mc == bmc {
+				return nil, err
+			}
+			// Controller may be absent or powered off
+			mc.Err = err
+			continue
+		}
+		mc.DeviceID = gdi
+	}
+
+	return mcs, nil
+}
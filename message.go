@@ -107,7 +107,19 @@ func sendMessage(conn net.Conn, req request, timeout time.Duration) (response, [
 		return nil, nil, err
 	}
 
-	buf = make([]byte, recvBufferSize)
+	return readMessage(conn)
+}
+
+// recvMessage Receives a message without sending a request
+func recvMessage(conn net.Conn, timeout time.Duration) (response, []byte, error) {
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, nil, err
+	}
+	return readMessage(conn)
+}
+
+func readMessage(conn net.Conn) (response, []byte, error) {
+	buf := make([]byte, recvBufferSize)
 	n, err := conn.Read(buf)
 	if err != nil {
 		return nil, nil, err
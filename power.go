@@ -0,0 +1,178 @@
+package ipmigo
+
+import (
+	"fmt"
+	"time"
+)
+
+// PowerController Chassis power control waiting for the power state change to be confirmed
+// by polling GetChassisStatusCommand. Each action returns the final observed chassis status.
+type PowerController struct {
+	Client          *Client
+	Timeout         time.Duration // Max wait for a power state change (The default is 1min)
+	SoftOffTimeout  time.Duration // Max wait for the ACPI shutdown of OS (The default is 5min)
+	SoftOffFallback bool          // Hard power off if soft-off is not confirmed in SoftOffTimeout
+	PollInterval    time.Duration // Interval between polls (The default is 1sec)
+
+	// Interval between polls for the off phase of power cycle, it must be shorter than
+	// the power cycle interval of BMC (The default is 100msec)
+	CyclePollInterval time.Duration
+}
+
+// A PowerTimeoutError suggests that the power state change is not confirmed in the timeout
+type PowerTimeoutError struct {
+	State   string        // Expected power state
+	Timeout time.Duration // Max wait
+	Cause   error         // Last error of Get Chassis Status, nil if the status was read
+}
+
+func (e *PowerTimeoutError) Error() string {
+	if e.Cause == nil {
+		return fmt.Sprintf("Chassis power state %s is not confirmed in %s", e.State, e.Timeout)
+	}
+	return fmt.Sprintf("Chassis power state %s is not confirmed in %s, cause `%v`", e.State, e.Timeout, e.Cause)
+}
+
+// NewPowerController Create a PowerController with default timeouts
+func NewPowerController(c *Client) *PowerController {
+	return &PowerController{
+		Client:            c,
+		Timeout:           time.Minute,
+		SoftOffTimeout:    5 * time.Minute,
+		PollInterval:      time.Second,
+		CyclePollInterval: 100 * time.Millisecond,
+	}
+}
+
+func (p *PowerController) status() (*GetChassisStatusCommand, error) {
+	gcs := &GetChassisStatusCommand{}
+	if err := p.Client.Execute(gcs); err != nil {
+		return nil, err
+	}
+	return gcs, nil
+}
+
+func (p *PowerController) control(cc ChassisControl) error {
+	return p.Client.Execute(&SetChassisControlCommand{ChassisControl: cc})
+}
+
+// wait Polls chassis status every PollInterval until done returns `true` or timeout expires
+func (p *PowerController) wait(timeout time.Duration, state string, done func(*GetChassisStatusCommand) bool) (*GetChassisStatusCommand, error) {
+	interval := p.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return p.poll(timeout, interval, state, done)
+}
+
+// poll Polls chassis status until done returns `true` or timeout expires (PowerTimeoutError)
+func (p *PowerController) poll(timeout, interval time.Duration, state string, done func(*GetChassisStatusCommand) bool) (*GetChassisStatusCommand, error) {
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+
+	var last *GetChassisStatusCommand
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := p.status()
+		if err != nil {
+			// BMC can be busy while the power state is changing
+			if _, ok := err.(*CommandError); !ok {
+				return last, err
+			}
+		} else {
+			last = status
+			if done(status) {
+				return status, nil
+			}
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return last, &PowerTimeoutError{State: state, Timeout: timeout, Cause: err}
+		}
+		time.Sleep(interval)
+	}
+}
+
+// On Powers up the chassis and waits for power on.
+func (p *PowerController) On() (*GetChassisStatusCommand, error) {
+	if err := p.control(ChassisControlPowerUp); err != nil {
+		return nil, err
+	}
+	return p.wait(p.Timeout, "on", func(s *GetChassisStatusCommand) bool { return s.PowerIsOn })
+}
+
+// Off Powers down the chassis immediately and waits for power off.
+func (p *PowerController) Off() (*GetChassisStatusCommand, error) {
+	if err := p.control(ChassisControlPowerDown); err != nil {
+		return nil, err
+	}
+	return p.wait(p.Timeout, "off", func(s *GetChassisStatusCommand) bool { return !s.PowerIsOn })
+}
+
+// SoftOff Initiates ACPI soft shutdown and waits for power off,
+// falls back to hard power off if SoftOffFallback is set.
+func (p *PowerController) SoftOff() (*GetChassisStatusCommand, error) {
+	if err := p.control(ChassisControlACPISoftShutdown); err != nil {
+		return nil, err
+	}
+
+	timeout := p.SoftOffTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+	status, err := p.wait(timeout, "soft-off", func(s *GetChassisStatusCommand) bool { return !s.PowerIsOn })
+	if err != nil && p.SoftOffFallback {
+		// Only if the OS did not shut down, other errors do not cut the power
+		if _, ok := err.(*PowerTimeoutError); ok {
+			return p.Off()
+		}
+	}
+	return status, err
+}
+
+// Cycle Power cycles the chassis and waits for power off and then on.
+// The off phase is polled every CyclePollInterval, PowerTimeoutError is returned
+// if it is not observed in Timeout.
+func (p *PowerController) Cycle() (*GetChassisStatusCommand, error) {
+	status, err := p.status()
+	if err != nil {
+		return nil, err
+	}
+	if !status.PowerIsOn {
+		// No action occurs if the chassis is powered off (Section 28.3)
+		return status, &MessageError{Message: "Chassis power is off, power cycle has no effect"}
+	}
+
+	if err := p.control(ChassisControlPowerCycle); err != nil {
+		return status, err
+	}
+
+	interval := p.CyclePollInterval
+	if interval <= 0 {
+		interval = 100 * time.Millisecond
+	}
+	status, err = p.poll(p.Timeout, interval, "cycle off", func(s *GetChassisStatusCommand) bool { return !s.PowerIsOn })
+	if err != nil {
+		return status, err
+	}
+	return p.wait(p.Timeout, "cycle on", func(s *GetChassisStatusCommand) bool { return s.PowerIsOn })
+}
+
+// Reset Hard resets the chassis and returns the chassis status.
+// The reset itself is not confirmed, only that the power is on.
+func (p *PowerController) Reset() (*GetChassisStatusCommand, error) {
+	if err := p.control(ChassisControlHardReset); err != nil {
+		return nil, err
+	}
+	return p.wait(p.Timeout, "on", func(s *GetChassisStatusCommand) bool { return s.PowerIsOn })
+}
+
+// DiagnosticInterrupt Pulses a diagnostic interrupt (NMI) and returns the chassis status read after it.
+// The interrupt is not confirmed and nothing is waited for.
+func (p *PowerController) DiagnosticInterrupt() (*GetChassisStatusCommand, error) {
+	if err := p.control(ChassisControlPulseDiagnosticInterrupt); err != nil {
+		return nil, err
+	}
+	return p.status()
+}
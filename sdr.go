@@ -1,10 +1,12 @@
 package ipmigo
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"math"
+	"strconv"
 )
 
 const (
@@ -15,10 +17,16 @@ const (
 	sdrHeaderSize       = 5
 	sdrDefaultReadBytes = 32
 
-	sdrCommonSensorSize     = 18
-	sdrFullSensorSize       = 25 + sdrCommonSensorSize
-	sdrCompactSensorSize    = 9 + sdrCommonSensorSize
-	sdrFRUDeviceLocatorSize = 11
+	sdrCommonSensorSize      = 18
+	sdrFullSensorSize        = 25 + sdrCommonSensorSize
+	sdrCompactSensorSize     = 9 + sdrCommonSensorSize
+	sdrEventOnlySensorSize   = 12
+	sdrEntityAssocSize       = 11
+	sdrDeviceEntityAssocSize = 21
+	sdrFRUDeviceLocatorSize  = 11
+	sdrGenericLocatorSize    = 11
+	sdrMCDeviceLocatorSize   = 11
+	sdrMCConfirmationSize    = 27
 )
 
 // SDRType Sensor Data Record Type
@@ -61,6 +69,10 @@ func (r *sdrHeader) Unmarshal(buf []byte) ([]byte, error) {
 	return buf[sdrHeaderSize:], nil
 }
 
+func (r *sdrHeader) Marshal() []byte {
+	return []byte{byte(r.RecordID), byte(r.RecordID >> 8), r.SDRVersion, byte(r.RecordType), r.RemainingBytes}
+}
+
 // SDR Sensor Data Record
 type SDR interface {
 	// Type Returns record type
@@ -71,15 +83,49 @@ type SDR interface {
 	Data() []byte
 }
 
+// sdrMarshalRecord Returns bytes of the record header, key and body
+func sdrMarshalRecord(r SDR) []byte {
+	data := r.Data()
+	header := &sdrHeader{RecordID: r.ID(), SDRVersion: 0x51, RecordType: r.Type(), RemainingBytes: uint8(len(data))}
+	if h, ok := r.(interface{ recordHeader() *sdrHeader }); ok {
+		header.SDRVersion = h.recordHeader().SDRVersion
+	}
+	return append(header.Marshal(), data...)
+}
+
+// sdrUnmarshalRecord Returns the record decoded from bytes of the record header, key and body,
+// and the remaining bytes
+func sdrUnmarshalRecord(args *Arguments, buf []byte) (SDR, []byte, error) {
+	header := &sdrHeader{}
+	body, err := header.Unmarshal(buf)
+	if err != nil {
+		return nil, nil, err
+	}
+	if l := len(body); l < int(header.RemainingBytes) {
+		return nil, nil, &MessageError{
+			Message: fmt.Sprintf("Invalid SDR record size : %d/%d", l, header.RemainingBytes),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	data := make([]byte, header.RemainingBytes)
+	copy(data, body)
+	r, err := sdrParseRecord(args, header, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, body[header.RemainingBytes:], nil
+}
+
 type sdrRaw struct {
 	header *sdrHeader
 	data   []byte
 }
 
-func (r *sdrRaw) Type() SDRType  { return r.header.RecordType }
-func (r *sdrRaw) ID() uint16     { return r.header.RecordID }
-func (r *sdrRaw) Data() []byte   { return r.data }
-func (r *sdrRaw) String() string { return hex.EncodeToString(r.data) }
+func (r *sdrRaw) Type() SDRType            { return r.header.RecordType }
+func (r *sdrRaw) ID() uint16               { return r.header.RecordID }
+func (r *sdrRaw) recordHeader() *sdrHeader { return r.header }
+func (r *sdrRaw) Data() []byte             { return r.data }
+func (r *sdrRaw) String() string           { return hex.EncodeToString(r.data) }
 
 func (r *sdrRaw) Unmarshal(buf []byte) ([]byte, error) {
 	r.data = buf
@@ -140,9 +186,10 @@ type SDRCommonSensor struct {
 	}
 }
 
-func (r *SDRCommonSensor) Type() SDRType { return r.header.RecordType }
-func (r *SDRCommonSensor) ID() uint16    { return r.header.RecordID }
-func (r *SDRCommonSensor) Data() []byte  { return r.data }
+func (r *SDRCommonSensor) Type() SDRType            { return r.header.RecordType }
+func (r *SDRCommonSensor) ID() uint16               { return r.header.RecordID }
+func (r *SDRCommonSensor) recordHeader() *sdrHeader { return r.header }
+func (r *SDRCommonSensor) Data() []byte             { return r.data }
 
 func (r *SDRCommonSensor) Unmarshal(buf []byte) ([]byte, error) {
 	if l := len(buf); l < sdrCommonSensorSize {
@@ -186,6 +233,40 @@ func (r *SDRCommonSensor) Unmarshal(buf []byte) ([]byte, error) {
 	return buf[sdrCommonSensorSize:], nil
 }
 
+// ReadableThresholds Returns the readable threshold mask of threshold-base sensor.
+func (r *SDRCommonSensor) ReadableThresholds() ThresholdMask {
+	if r.EventReadingType != 0x01 {
+		return 0
+	}
+	return ThresholdMask(r.Mask.DiscreteOrReadableThreshold & 0x3f)
+}
+
+// SettableThresholds Returns the settable threshold mask of threshold-base sensor.
+func (r *SDRCommonSensor) SettableThresholds() ThresholdMask {
+	if r.EventReadingType != 0x01 {
+		return 0
+	}
+	return ThresholdMask(r.Mask.DiscreteOrReadableThreshold >> 8 & 0x3f)
+}
+
+// AssertionEventMask Returns the assertion event mask of the record.
+func (r *SDRCommonSensor) AssertionEventMask() EventMask {
+	return r.eventMask(r.Mask.AssertionOrLowerThreshold)
+}
+
+// DeassertionEventMask Returns the deassertion event mask of the record.
+func (r *SDRCommonSensor) DeassertionEventMask() EventMask {
+	return r.eventMask(r.Mask.DeassertionOrUpperThreshold)
+}
+
+func (r *SDRCommonSensor) eventMask(mask uint16) EventMask {
+	if r.EventReadingType == 0x01 {
+		// Upper bits are reading masks of threshold-base sensor
+		return EventMask(mask) & eventMaskThreshold
+	}
+	return EventMask(mask) & eventMaskDiscrete
+}
+
 func (r *SDRCommonSensor) UnitString() string {
 	var s string
 	switch r.SensorUnits.Modifier {
@@ -246,6 +327,8 @@ type SDRFullSensor struct {
 	IDType   uint8
 	IDLength uint8
 	IDString []byte
+
+	factors map[uint8]*SensorFactors // Reading factors of non-linear sensor
 }
 
 func (r *SDRFullSensor) Unmarshal(buf []byte) ([]byte, error) {
@@ -266,7 +349,7 @@ func (r *SDRFullSensor) Unmarshal(buf []byte) ([]byte, error) {
 	r.Tolerance = buf[2] & 0x3f
 	r.B = tos16(uint16(buf[3])|uint16(buf[4]&0xc0)<<2, 10)
 	r.Accuracy = uint16(buf[4]&0x3f) | uint16(buf[5]&0xf0)<<2
-	r.AccuracyExp = buf[5] & 0x0c
+	r.AccuracyExp = buf[5] & 0x0c >> 2
 	r.RExp = int8(tos16(uint16(buf[6]&0xf0)>>4, 4))
 	r.BExp = int8(tos16(uint16(buf[6]&0x0f), 4))
 	r.AnalogFlags.NominalRead = buf[7]&0x01 != 0
@@ -319,29 +402,96 @@ func (r *SDRFullSensor) IsAnalogReading() bool {
 	return r.SensorUnits.Analog < 0x03 && r.IsThresholdBaseSensor()
 }
 
-// ConvertSensorReading Returns converted sensor reading.
-func (r *SDRFullSensor) ConvertSensorReading(value uint8) float64 {
-	var result float64
+// NominalReading Returns the nominal reading in engineering units, `false` if not specified.
+func (r *SDRFullSensor) NominalReading() (float64, bool) {
+	return r.ConvertSensorReading(r.NominalRead), r.AnalogFlags.NominalRead
+}
 
-	// Conversion Formula (Section 36.3)
-	switch r.SensorUnits.Analog {
-	// unsigned
-	case 0:
-		result = (float64(int(r.M)*int(value)) + float64(r.B)*math.Pow10(int(r.BExp))) * math.Pow10(int(r.RExp))
-	// 1's complement
-	case 1:
-		if value&0x80 != 0 {
-			value += 1
+// NormalMaximum Returns the normal maximum in engineering units, `false` if not specified.
+func (r *SDRFullSensor) NormalMaximum() (float64, bool) {
+	return r.ConvertSensorReading(r.NormalMax), r.AnalogFlags.NormalMax
+}
+
+// NormalMinimum Returns the normal minimum in engineering units, `false` if not specified.
+func (r *SDRFullSensor) NormalMinimum() (float64, bool) {
+	return r.ConvertSensorReading(r.NormalMin), r.AnalogFlags.NormalMin
+}
+
+// SensorMaximum Returns the sensor maximum reading in engineering units.
+func (r *SDRFullSensor) SensorMaximum() float64 {
+	return r.ConvertSensorReading(r.SensorMax)
+}
+
+// SensorMinimum Returns the sensor minimum reading in engineering units.
+func (r *SDRFullSensor) SensorMinimum() float64 {
+	return r.ConvertSensorReading(r.SensorMin)
+}
+
+// DefaultThresholds Returns the readable thresholds of the record in engineering units.
+// These are the values the BMC initializes the sensor with, use Get Sensor Thresholds Command for the current ones.
+func (r *SDRFullSensor) DefaultThresholds() map[ThresholdStatus]float64 {
+	if !r.IsThresholdBaseSensor() {
+		return nil
+	}
+
+	readable := r.ReadableThresholds()
+	thresholds := make(map[ThresholdStatus]float64)
+	for _, t := range []struct {
+		mask   ThresholdMask
+		status ThresholdStatus
+		raw    uint8
+	}{
+		{ThresholdMaskLNR, ThresholdStatusLNR, r.Threshold.LowerNonRecover},
+		{ThresholdMaskLCR, ThresholdStatusLCR, r.Threshold.LowerCrit},
+		{ThresholdMaskLNC, ThresholdStatusLNC, r.Threshold.LowerNonCrit},
+		{ThresholdMaskUNC, ThresholdStatusUNC, r.Threshold.UpperNonCrit},
+		{ThresholdMaskUCR, ThresholdStatusUCR, r.Threshold.UpperCrit},
+		{ThresholdMaskUNR, ThresholdStatusUNR, r.Threshold.UpperNonRecover},
+	} {
+		if readable.Has(t.mask) {
+			thresholds[t.status] = r.ConvertSensorReading(t.raw)
 		}
-		fallthrough
-	// 2's complement
-	case 2:
-		result = (float64(int(r.M)*int(int8(value))) + float64(r.B)*math.Pow10(int(r.BExp))) * math.Pow10(int(r.RExp))
-	default:
+	}
+	return thresholds
+}
+
+// DefaultHysteresis Returns the positive and negative going hysteresis of the record in engineering units.
+func (r *SDRFullSensor) DefaultHysteresis() (positive, negative float64) {
+	return r.convertDelta(float64(r.Threshold.PositiveHysteresis)), r.convertDelta(float64(r.Threshold.NegativeHysteresis))
+}
+
+// ToleranceValue Returns the tolerance in engineering units (+/-).
+func (r *SDRFullSensor) ToleranceValue() float64 {
+	// Tolerance is in +/- 1/2 raw counts
+	return r.convertDelta(float64(r.Tolerance) / 2)
+}
+
+// AccuracyPercent Returns the accuracy in percent.
+func (r *SDRFullSensor) AccuracyPercent() float64 {
+	// Accuracy is in 1/100 percent scaled up by the accuracy exponent
+	return float64(r.Accuracy) * math.Pow10(int(r.AccuracyExp)) / 100
+}
+
+// convertDelta Converts raw counts of a difference (hysteresis, tolerance) to engineering units
+func (r *SDRFullSensor) convertDelta(counts float64) float64 {
+	return math.Abs(float64(r.M) * counts * math.Pow10(int(r.RExp)))
+}
+
+// ConvertSensorReading Returns converted sensor reading.
+// Non-linear sensors are converted with factors fetched by ConvertSensorReadingFactors,
+// otherwise the record factors are used.
+func (r *SDRFullSensor) ConvertSensorReading(value uint8) float64 {
+	if r.SensorUnits.Analog > 0x02 {
 		// Not analog sensor
 		return 0.0
 	}
 
+	factors := r.factors[value]
+	if factors == nil {
+		factors = &SensorFactors{M: r.M, B: r.B, RExp: r.RExp, BExp: r.BExp}
+	}
+	result := factors.Convert(r.SensorUnits.Analog, value)
+
 	switch r.Linearization {
 	case 0x01:
 		return math.Log(result)
@@ -372,6 +522,135 @@ func (r *SDRFullSensor) ConvertSensorReading(value uint8) float64 {
 	}
 }
 
+// IsNonLinear Returns `true` if the conversion factors depend on the reading.
+func (r *SDRFullSensor) IsNonLinear() bool {
+	return r.Linearization >= 0x70 && r.Linearization <= 0x7f
+}
+
+// ConvertSensorReadingFactors Returns converted sensor reading, fetching the reading factors
+// of non-linear sensor from BMC. Factors are cached per reading value.
+func (r *SDRFullSensor) ConvertSensorReadingFactors(c *Client, value uint8) (float64, error) {
+	if r.IsNonLinear() && r.factors[value] == nil {
+		if _, err := r.ReadingFactors(c, value); err != nil {
+			return 0.0, err
+		}
+	}
+	return r.ConvertSensorReading(value), nil
+}
+
+// ReadingFactors Returns the conversion factors for the reading value,
+// fetched by Get Sensor Reading Factors Command for non-linear sensor.
+func (r *SDRFullSensor) ReadingFactors(c *Client, value uint8) (*SensorFactors, error) {
+	if f := r.factors[value]; f != nil {
+		return f, nil
+	}
+	if !r.IsNonLinear() {
+		return &SensorFactors{
+			M:           r.M,
+			Tolerance:   r.Tolerance,
+			B:           r.B,
+			Accuracy:    r.Accuracy,
+			AccuracyExp: r.AccuracyExp,
+			RExp:        r.RExp,
+			BExp:        r.BExp,
+		}, nil
+	}
+
+	gfc := &GetSensorReadingFactorsCommand{
+		RsLUN:        r.OwnerLUN,
+		SensorNumber: r.SensorNumber,
+		Reading:      value,
+	}
+	if err := SensorExecute(c, &r.SDRCommonSensor, gfc); err != nil {
+		return nil, err
+	}
+
+	if r.factors == nil {
+		r.factors = make(map[uint8]*SensorFactors)
+	}
+	f := &gfc.Factors
+	r.factors[value] = f
+	// Factors are the same up to the next reading
+	for v := int(value) + 1; v < int(gfc.NextReading); v++ {
+		r.factors[uint8(v)] = f
+	}
+	return f, nil
+}
+
+// ConvertToSensorReading Returns the raw sensor reading for a value in engineering units,
+// the inverse of ConvertSensorReading (e.g. for Set Sensor Thresholds Command).
+func (r *SDRFullSensor) ConvertToSensorReading(value float64) (uint8, error) {
+	if r.SensorUnits.Analog > 0x02 {
+		return 0, &ArgumentError{Value: r.SensorNumber, Message: "Not analog sensor"}
+	}
+	if r.M == 0 {
+		return 0, &ArgumentError{Value: r.SensorNumber, Message: "Sensor has zero M factor"}
+	}
+
+	// Inverse of linearization
+	switch r.Linearization {
+	case 0x00:
+	case 0x01:
+		value = math.Exp(value)
+	case 0x02:
+		value = math.Pow(10, value)
+	case 0x03:
+		value = math.Exp2(value)
+	case 0x04:
+		value = math.Log(value)
+	case 0x05:
+		value = math.Log10(value)
+	case 0x06:
+		value = math.Log2(value)
+	case 0x07:
+		value = math.Pow(value, -1.0)
+	case 0x08:
+		value = math.Sqrt(value)
+	case 0x09:
+		value = math.Cbrt(value)
+	case 0x0a:
+		value = math.Pow(value, 2.0)
+	case 0x0b:
+		value = math.Pow(value, 3.0)
+	default:
+		return 0, &ArgumentError{
+			Value:   r.Linearization,
+			Message: "Unsupported sensor linearization",
+		}
+	}
+
+	// Conversion Formula (Section 36.3) solved for the raw reading
+	raw := math.Round((value*math.Pow10(-int(r.RExp)) - float64(r.B)*math.Pow10(int(r.BExp))) / float64(r.M))
+	if math.IsNaN(raw) || math.IsInf(raw, 0) {
+		return 0, &ArgumentError{Value: value, Message: "Value cannot be converted to sensor reading"}
+	}
+
+	switch r.SensorUnits.Analog {
+	// unsigned
+	case 0:
+		if raw < 0 || raw > 255 {
+			break
+		}
+		return uint8(raw), nil
+	// 1's complement
+	case 1:
+		if raw < -127 || raw > 127 {
+			break
+		}
+		if raw < 0 {
+			return ^uint8(-raw), nil
+		}
+		return uint8(raw), nil
+	// 2's complement
+	case 2:
+		if raw < -128 || raw > 127 {
+			break
+		}
+		return uint8(int8(raw)), nil
+	}
+	return 0, &ArgumentError{Value: value, Message: "Value is out of sensor reading range"}
+}
+
 // SDRCompactSensor Compact Sensor Record (Section 43.2)
 type SDRCompactSensor struct {
 	SDRCommonSensor
@@ -430,6 +709,188 @@ func (r *SDRCompactSensor) SensorID() string {
 	return decodeSensorID(r.IDType, r.IDString)
 }
 
+// SharedSensorID Returns the ID string of the sensor number + i with the instance modifier.
+func (r *SDRCompactSensor) SharedSensorID(i int) string {
+	return sharedSensorID(r.SensorID(), r.Share.Count, r.Share.ModifierType, r.Share.ModifierOffset, i)
+}
+
+// SDREventOnlySensor Event-Only Record (Section 43.3)
+type SDREventOnlySensor struct {
+	header *sdrHeader
+	data   []byte
+
+	OwnerID       uint8
+	OwnerLUN      uint8
+	FRUOwnerLUN   uint8
+	ChannelNumber uint8
+	SensorNumber  uint8
+
+	Entity struct {
+		ID       uint8 // (See Table 43-13)
+		Instance uint8
+		Logical  bool
+	}
+
+	SensorType       SensorType
+	EventReadingType uint8 // (See Table 42-1)
+	SensorDirection  uint8 // (0: unspecified, 1: input, 2: output)
+
+	Share struct {
+		Count          uint8
+		ModifierType   uint8 // (0: numeric, 1: alpha)
+		ModifierOffset uint8
+		EntityInstance uint8 // (0: same, 1: increments)
+	}
+
+	OEM      uint8
+	IDType   uint8
+	IDLength uint8
+	IDString []byte
+}
+
+func (r *SDREventOnlySensor) Type() SDRType            { return r.header.RecordType }
+func (r *SDREventOnlySensor) ID() uint16               { return r.header.RecordID }
+func (r *SDREventOnlySensor) recordHeader() *sdrHeader { return r.header }
+func (r *SDREventOnlySensor) Data() []byte             { return r.data }
+
+func (r *SDREventOnlySensor) Unmarshal(buf []byte) ([]byte, error) {
+	if l := len(buf); l < sdrEventOnlySensorSize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid SDREventOnlySensor size : %d/%d", l, sdrEventOnlySensorSize),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	r.data = buf
+	r.OwnerID = buf[0]
+	r.OwnerLUN = buf[1] & 0x03
+	r.FRUOwnerLUN = buf[1] & 0x0c >> 2
+	r.ChannelNumber = buf[1] & 0xf0 >> 4
+	r.SensorNumber = buf[2]
+	r.Entity.ID = buf[3]
+	r.Entity.Instance = buf[4] & 0x7f
+	r.Entity.Logical = buf[4]&0x80 != 0
+	r.SensorType = SensorType(buf[5])
+	r.EventReadingType = buf[6]
+	r.SensorDirection = buf[7] & 0xc0 >> 6
+	r.Share.Count = buf[7] & 0x0f
+	r.Share.ModifierType = buf[7] & 0x30 >> 4
+	r.Share.ModifierOffset = buf[8] & 0x7f
+	r.Share.EntityInstance = buf[8] & 0x80 >> 7
+	r.OEM = buf[10]
+	r.IDType = buf[11] & 0xc0 >> 6
+	r.IDLength = buf[11] & 0x1f
+	if l := int(r.IDLength); l > 0 {
+		r.IDString = buf[12:]
+		if l < len(r.IDString) {
+			r.IDString = r.IDString[:l]
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *SDREventOnlySensor) SensorID() string {
+	return decodeSensorID(r.IDType, r.IDString)
+}
+
+// SharedSensorID Returns the ID string of the sensor number + i with the instance modifier.
+func (r *SDREventOnlySensor) SharedSensorID(i int) string {
+	return sharedSensorID(r.SensorID(), r.Share.Count, r.Share.ModifierType, r.Share.ModifierOffset, i)
+}
+
+// SDREntityAssociation Entity Association Record (Section 43.4)
+type SDREntityAssociation struct {
+	header *sdrHeader
+	data   []byte
+
+	Container Entity
+	Range     bool // `true`: contained entities are specified as ranges (1-2, 3-4)
+	Linked    bool // Other records with the same container exist
+	Presence  bool // Presence sensor should be always accessible
+
+	Contained [4]Entity
+}
+
+func (r *SDREntityAssociation) Type() SDRType            { return r.header.RecordType }
+func (r *SDREntityAssociation) ID() uint16               { return r.header.RecordID }
+func (r *SDREntityAssociation) recordHeader() *sdrHeader { return r.header }
+func (r *SDREntityAssociation) Data() []byte             { return r.data }
+
+func (r *SDREntityAssociation) Unmarshal(buf []byte) ([]byte, error) {
+	if l := len(buf); l < sdrEntityAssocSize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid SDREntityAssociation size : %d/%d", l, sdrEntityAssocSize),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	r.data = buf
+	r.Container = Entity{ID: EntityID(buf[0]), Instance: buf[1]}
+	r.Range = buf[2]&0x80 != 0
+	r.Linked = buf[2]&0x40 != 0
+	r.Presence = buf[2]&0x20 != 0
+	for i := range r.Contained {
+		r.Contained[i] = Entity{ID: EntityID(buf[3+i*2]), Instance: buf[4+i*2]}
+	}
+	return nil, nil
+}
+
+// Entities Returns the contained entities, ranges are expanded.
+func (r *SDREntityAssociation) Entities() []Entity {
+	return containedEntities(r.Range, r.Contained[:])
+}
+
+// SDRDeviceEntityAssociation Device-relative Entity Association Record (Section 43.5)
+type SDRDeviceEntityAssociation struct {
+	header *sdrHeader
+	data   []byte
+
+	Container Entity // DeviceAddress and Channel are of the container entity device
+	Range     bool   // `true`: contained entities are specified as ranges (1-2, 3-4)
+	Linked    bool   // Other records with the same container exist
+	Presence  bool   // Presence sensor should be always accessible
+
+	Contained [4]Entity
+}
+
+func (r *SDRDeviceEntityAssociation) Type() SDRType            { return r.header.RecordType }
+func (r *SDRDeviceEntityAssociation) ID() uint16               { return r.header.RecordID }
+func (r *SDRDeviceEntityAssociation) recordHeader() *sdrHeader { return r.header }
+func (r *SDRDeviceEntityAssociation) Data() []byte             { return r.data }
+
+func (r *SDRDeviceEntityAssociation) Unmarshal(buf []byte) ([]byte, error) {
+	if l := len(buf); l < sdrDeviceEntityAssocSize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid SDRDeviceEntityAssociation size : %d/%d", l, sdrDeviceEntityAssocSize),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	r.data = buf
+	r.Container = Entity{
+		ID:            EntityID(buf[0]),
+		Instance:      buf[1],
+		DeviceAddress: buf[2] & 0xfe,
+		Channel:       buf[3] & 0xf0 >> 4,
+	}
+	r.Range = buf[4]&0x80 != 0
+	r.Linked = buf[4]&0x40 != 0
+	r.Presence = buf[4]&0x20 != 0
+	for i := range r.Contained {
+		b := buf[5+i*4:]
+		r.Contained[i] = Entity{
+			ID:            EntityID(b[2]),
+			Instance:      b[3],
+			DeviceAddress: b[0] & 0xfe,
+			Channel:       b[1] & 0xf0 >> 4,
+		}
+	}
+	return nil, nil
+}
+
+// Entities Returns the contained entities, ranges are expanded.
+func (r *SDRDeviceEntityAssociation) Entities() []Entity {
+	return containedEntities(r.Range, r.Contained[:])
+}
+
 // SDRFRUDeviceLocator FRU Device Locator Record (Section 43.8)
 type SDRFRUDeviceLocator struct {
 	header *sdrHeader
@@ -455,9 +916,10 @@ type SDRFRUDeviceLocator struct {
 	IDString []byte
 }
 
-func (r *SDRFRUDeviceLocator) Type() SDRType { return r.header.RecordType }
-func (r *SDRFRUDeviceLocator) ID() uint16    { return r.header.RecordID }
-func (r *SDRFRUDeviceLocator) Data() []byte  { return r.data }
+func (r *SDRFRUDeviceLocator) Type() SDRType            { return r.header.RecordType }
+func (r *SDRFRUDeviceLocator) ID() uint16               { return r.header.RecordID }
+func (r *SDRFRUDeviceLocator) recordHeader() *sdrHeader { return r.header }
+func (r *SDRFRUDeviceLocator) Data() []byte             { return r.data }
 
 func (r *SDRFRUDeviceLocator) Unmarshal(buf []byte) ([]byte, error) {
 	if l := len(buf); l < sdrFRUDeviceLocatorSize {
@@ -494,12 +956,221 @@ func (r *SDRFRUDeviceLocator) SensorID() string {
 	return decodeSensorID(r.IDType, r.IDString)
 }
 
+// SDRGenericDeviceLocator Generic Device Locator Record (Section 43.7)
+type SDRGenericDeviceLocator struct {
+	header *sdrHeader
+	data   []byte
+
+	AccessAddress      uint8 // Controller used to access the device (7-bit), 0 if device is on IPMB
+	SlaveAddress       uint8 // Device slave address (7-bit)
+	ChannelNumber      uint8
+	AccessLUN          uint8
+	BusID              uint8 // Private bus ID
+	AddressSpan        uint8 // Number of additional consecutive addresses used by the device
+	DeviceType         uint8 // (See Table 43-12)
+	DeviceTypeModifier uint8
+
+	Entity struct {
+		ID       uint8
+		Instance uint8
+	}
+
+	OEM      uint8
+	IDType   uint8
+	IDLength uint8
+	IDString []byte
+}
+
+func (r *SDRGenericDeviceLocator) Type() SDRType            { return r.header.RecordType }
+func (r *SDRGenericDeviceLocator) ID() uint16               { return r.header.RecordID }
+func (r *SDRGenericDeviceLocator) recordHeader() *sdrHeader { return r.header }
+func (r *SDRGenericDeviceLocator) Data() []byte             { return r.data }
+
+func (r *SDRGenericDeviceLocator) Unmarshal(buf []byte) ([]byte, error) {
+	if l := len(buf); l < sdrGenericLocatorSize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid SDRGenericDeviceLocator size : %d/%d", l, sdrGenericLocatorSize),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	r.data = buf
+	r.AccessAddress = buf[0] & 0xfe >> 1
+	r.SlaveAddress = buf[1] & 0xfe >> 1
+	r.ChannelNumber = buf[2]&0xe0>>5 | buf[1]&0x01<<3
+	r.AccessLUN = buf[2] & 0x18 >> 3
+	r.BusID = buf[2] & 0x07
+	r.AddressSpan = buf[3] & 0x07
+	r.DeviceType = buf[5]
+	r.DeviceTypeModifier = buf[6]
+	r.Entity.ID = buf[7]
+	r.Entity.Instance = buf[8]
+	r.OEM = buf[9]
+	r.IDType = buf[10] & 0xc0 >> 6
+	r.IDLength = buf[10] & 0x1f
+	if l := int(r.IDLength); l > 0 {
+		r.IDString = buf[11:]
+		if l < len(r.IDString) {
+			r.IDString = r.IDString[:l]
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *SDRGenericDeviceLocator) SensorID() string {
+	return decodeSensorID(r.IDType, r.IDString)
+}
+
+// SDRMCDeviceLocator Management Controller Device Locator Record (Section 43.9)
+type SDRMCDeviceLocator struct {
+	header *sdrHeader
+	data   []byte
+
+	SlaveAddress  uint8 // (7-bit)
+	ChannelNumber uint8
+
+	ACPISystemPowerNotification bool
+	ACPIDevicePowerNotification bool
+	ControllerLogsInitErrors    bool
+	LogInitErrors               bool
+	GlobalInit                  uint8 // (0: enable event message generation, 1: disable, 2: do not initialize)
+
+	Capabilities struct {
+		ChassisDevice       bool
+		Bridge              bool
+		EventGenerator      bool // IPMB Event Generator
+		EventReceiver       bool // IPMB Event Receiver
+		FRUInventoryDevice  bool
+		SELDevice           bool
+		SDRRepositoryDevice bool
+		SensorDevice        bool
+	}
+
+	Entity struct {
+		ID       uint8
+		Instance uint8
+	}
+
+	OEM      uint8
+	IDType   uint8
+	IDLength uint8
+	IDString []byte
+}
+
+func (r *SDRMCDeviceLocator) Type() SDRType            { return r.header.RecordType }
+func (r *SDRMCDeviceLocator) ID() uint16               { return r.header.RecordID }
+func (r *SDRMCDeviceLocator) recordHeader() *sdrHeader { return r.header }
+func (r *SDRMCDeviceLocator) Data() []byte             { return r.data }
+
+func (r *SDRMCDeviceLocator) Unmarshal(buf []byte) ([]byte, error) {
+	if l := len(buf); l < sdrMCDeviceLocatorSize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid SDRMCDeviceLocator size : %d/%d", l, sdrMCDeviceLocatorSize),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	r.data = buf
+	r.SlaveAddress = buf[0] & 0xfe >> 1
+	r.ChannelNumber = buf[1] & 0x0f
+	r.ACPISystemPowerNotification = buf[2]&0x80 != 0
+	r.ACPIDevicePowerNotification = buf[2]&0x40 != 0
+	r.ControllerLogsInitErrors = buf[2]&0x08 != 0
+	r.LogInitErrors = buf[2]&0x04 != 0
+	r.GlobalInit = buf[2] & 0x03
+	r.Capabilities.ChassisDevice = buf[3]&0x80 != 0
+	r.Capabilities.Bridge = buf[3]&0x40 != 0
+	r.Capabilities.EventGenerator = buf[3]&0x20 != 0
+	r.Capabilities.EventReceiver = buf[3]&0x10 != 0
+	r.Capabilities.FRUInventoryDevice = buf[3]&0x08 != 0
+	r.Capabilities.SELDevice = buf[3]&0x04 != 0
+	r.Capabilities.SDRRepositoryDevice = buf[3]&0x02 != 0
+	r.Capabilities.SensorDevice = buf[3]&0x01 != 0
+	r.Entity.ID = buf[7]
+	r.Entity.Instance = buf[8]
+	r.OEM = buf[9]
+	r.IDType = buf[10] & 0xc0 >> 6
+	r.IDLength = buf[10] & 0x1f
+	if l := int(r.IDLength); l > 0 {
+		r.IDString = buf[11:]
+		if l < len(r.IDString) {
+			r.IDString = r.IDString[:l]
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *SDRMCDeviceLocator) SensorID() string {
+	return decodeSensorID(r.IDType, r.IDString)
+}
+
+// SDRMCConfirmation Management Controller Confirmation Record (Section 43.10)
+type SDRMCConfirmation struct {
+	header *sdrHeader
+	data   []byte
+
+	SlaveAddress          uint8 // (7-bit)
+	DeviceID              uint8
+	ChannelNumber         uint8
+	DeviceRevision        uint8
+	FirmwareMajorRevision uint8
+	FirmwareMinorRevision uint8 // BCD encoded
+	IPMIVersion           uint8
+	ManufacturerID        uint32
+	ProductID             uint16
+	DeviceGUID            [16]byte
+}
+
+func (r *SDRMCConfirmation) Type() SDRType            { return r.header.RecordType }
+func (r *SDRMCConfirmation) ID() uint16               { return r.header.RecordID }
+func (r *SDRMCConfirmation) recordHeader() *sdrHeader { return r.header }
+func (r *SDRMCConfirmation) Data() []byte             { return r.data }
+
+func (r *SDRMCConfirmation) Unmarshal(buf []byte) ([]byte, error) {
+	if l := len(buf); l < sdrMCConfirmationSize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid SDRMCConfirmation size : %d/%d", l, sdrMCConfirmationSize),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	r.data = buf
+	r.SlaveAddress = buf[0] & 0xfe >> 1
+	r.DeviceID = buf[1]
+	r.ChannelNumber = buf[2] & 0xf0 >> 4
+	r.DeviceRevision = buf[2] & 0x0f
+	r.FirmwareMajorRevision = buf[3] & 0x7f
+	r.FirmwareMinorRevision = buf[4]
+	r.IPMIVersion = buf[5]
+	r.ManufacturerID = uint32(buf[6]) | uint32(buf[7])<<8 | uint32(buf[8])<<16
+	r.ProductID = binary.LittleEndian.Uint16(buf[9:11])
+	copy(r.DeviceGUID[:], buf[11:27])
+
+	return buf[sdrMCConfirmationSize:], nil
+}
+
 // Two's complement to signed int16
 func tos16(n uint16, bits int) int16 {
 	shift := uint(16 - bits)
 	return int16(n<<shift) >> shift
 }
 
+// sharedSensorID Returns the ID string of i-th sensor of a shared record with the instance modifier (Section 43.2)
+func sharedSensorID(id string, count, modifierType, modifierOffset uint8, i int) string {
+	if count <= 1 {
+		return id
+	}
+	n := int(modifierOffset) + i
+	if modifierType == 0x01 {
+		// Alpha (0: A, 25: Z, 26: AA ...)
+		m := ""
+		for ; n >= 0; n = n/26 - 1 {
+			m = string(rune('A'+n%26)) + m
+		}
+		return id + m
+	}
+	return id + strconv.Itoa(n)
+}
+
 func decodeSensorID(t uint8, b []byte) string {
 	// Support only 8-bit ASCII (Section 43.15)
 	switch t {
@@ -509,14 +1180,17 @@ func decodeSensorID(t uint8, b []byte) string {
 	return "0x" + hex.EncodeToString(b)
 }
 
-func sdrGetRecordHeaderAndNextID(c *Client, reservation, recordID uint16) (*sdrHeader, uint16, error) {
+// sdrGetFunc Executes Get SDR (or Get Device SDR) Command
+type sdrGetFunc func(gsc *GetSDRCommand) error
+
+func sdrGetRecordHeaderAndNextID(get sdrGetFunc, reservation, recordID uint16) (*sdrHeader, uint16, error) {
 	gsc := &GetSDRCommand{
 		ReservationID: reservation,
 		RecordID:      recordID,
 		RecordOffset:  0,
 		ReadBytes:     sdrHeaderSize,
 	}
-	if err := c.Execute(gsc); err != nil {
+	if err := get(gsc); err != nil {
 		return nil, 0, err
 	}
 
@@ -531,7 +1205,7 @@ func sdrGetRecordHeaderAndNextID(c *Client, reservation, recordID uint16) (*sdrH
 	return header, gsc.NextRecordID, nil
 }
 
-func sdrGetRecord(c *Client, reservation uint16, header *sdrHeader) (SDR, error) {
+func sdrGetRecord(c *Client, get sdrGetFunc, reservation uint16, header *sdrHeader) (SDR, error) {
 	buf := make([]byte, header.RemainingBytes)
 
 	for n := uint8(0); n < header.RemainingBytes; {
@@ -546,7 +1220,7 @@ func sdrGetRecord(c *Client, reservation uint16, header *sdrHeader) (SDR, error)
 			RecordOffset:  n + sdrHeaderSize,
 			ReadBytes:     r,
 		}
-		if err := c.Execute(gsc); err != nil {
+		if err := get(gsc); err != nil {
 			// Adjust to the upper limit that BMC can be responded
 			if e, ok := err.(*CommandError); ok && e.CompletionCode == CompletionRequestDataFieldExceedEd {
 				if c.sdrReadingBytes > sdrHeaderSize {
@@ -563,20 +1237,66 @@ func sdrGetRecord(c *Client, reservation uint16, header *sdrHeader) (SDR, error)
 		n += uint8(len(gsc.RecordData))
 	}
 
-	// TODO Add a new record type
+	return sdrParseRecord(c.args, header, buf)
+}
+
+// sdrParseRecord Returns the record decoded from the record key and body
+func sdrParseRecord(args *Arguments, header *sdrHeader, buf []byte) (SDR, error) {
 	switch t := header.RecordType; t {
 	case SDRTypeFullSensor:
-		r := &SDRFullSensor{SDRCommonSensor: SDRCommonSensor{args: c.args, header: header}}
+		r := &SDRFullSensor{SDRCommonSensor: SDRCommonSensor{args: args, header: header}}
 		if _, err := r.Unmarshal(buf); err != nil {
 			return nil, err
 		}
 		return r, nil
 	case SDRTypeCompactSensor:
-		r := &SDRCompactSensor{SDRCommonSensor: SDRCommonSensor{args: c.args, header: header}}
+		r := &SDRCompactSensor{SDRCommonSensor: SDRCommonSensor{args: args, header: header}}
+		if _, err := r.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		return r, nil
+	case SDRTypeEventOnlySensor:
+		r := &SDREventOnlySensor{header: header}
+		if _, err := r.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		return r, nil
+	case SDRTypeEntityAssociation:
+		r := &SDREntityAssociation{header: header}
+		if _, err := r.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		return r, nil
+	case SDRTypeDeviceEntityAssociation:
+		r := &SDRDeviceEntityAssociation{header: header}
+		if _, err := r.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		return r, nil
+	case SDRTypeGenericDeviceLocator:
+		r := &SDRGenericDeviceLocator{header: header}
+		if _, err := r.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		return r, nil
+	case SDRTypeMCDeviceLocator:
+		r := &SDRMCDeviceLocator{header: header}
 		if _, err := r.Unmarshal(buf); err != nil {
 			return nil, err
 		}
 		return r, nil
+	case SDRTypeMCConfirmation:
+		r := &SDRMCConfirmation{header: header}
+		if _, err := r.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		return r, nil
+	case SDRTypeOEM:
+		r := &SDROEM{header: header}
+		if _, err := r.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		return sdrDecodeOEM(r), nil
 	case SDRTypeFRUDeviceLocator:
 		r := &SDRFRUDeviceLocator{header: header}
 		if _, err := r.Unmarshal(buf); err != nil {
@@ -598,6 +1318,11 @@ func SDRGetAllRecordsRepo(c *Client) ([]SDR, error) {
 
 // SDRGetRecordsRepo Returns sensor records from SDR repository.
 func SDRGetRecordsRepo(c *Client, filter func(id uint16, t SDRType) bool) ([]SDR, error) {
+	return sdrGetRecordsRepo(context.Background(), c, filter)
+}
+
+// sdrGetRecordsRepo Returns sensor records from SDR repository, stops reading when ctx is done
+func sdrGetRecordsRepo(ctx context.Context, c *Client, filter func(id uint16, t SDRType) bool) ([]SDR, error) {
 	gic := &GetSDRRepositoryInfoCommand{}
 	if err := c.Execute(gic); err != nil {
 		return nil, err
@@ -614,24 +1339,122 @@ func SDRGetRecordsRepo(c *Client, filter func(id uint16, t SDRType) bool) ([]SDR
 		}
 	}
 
-	sensors := make([]SDR, 0, gic.RecordCount)
+	reserve := func() (uint16, error) {
+		rsc := &ReserveSDRRepositoryCommand{}
+		if err := c.Execute(rsc); err != nil {
+			return 0, err
+		}
+		return rsc.ReservationID, nil
+	}
+	get := func(gsc *GetSDRCommand) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return c.Execute(gsc)
+	}
+
+	return sdrGetRecords(c, reserve, get, int(gic.RecordCount), filter)
+}
+
+// SDRGetRecordsDevice Returns sensor records from device SDRs of the controller (8-bit slave address form)
+// on the channel, records of all LUNs having sensors are read. Channel 0 and address 0x20 is the BMC itself.
+// A LUN with dynamic sensor population is read again if the population changes while reading it.
+func SDRGetRecordsDevice(c *Client, channel, address uint8, filter func(id uint16, t SDRType) bool) ([]SDR, error) {
+	return sdrGetRecordsDevice(c, func(cmd Command) error {
+		return c.Execute(NewBridgedCommand(channel, address, cmd))
+	}, filter)
+}
+
+const sdrDevicePopulationRetries = 3
+
+func sdrGetRecordsDevice(c *Client, execute func(cmd Command) error,
+	filter func(id uint16, t SDRType) bool) ([]SDR, error) {
+	gic := &GetDeviceSDRInfoCommand{SDRCount: true}
+	if err := execute(gic); err != nil {
+		return nil, err
+	}
+
+	sensors := []SDR{}
+	for i, ok := range gic.LUNHasSensors {
+		if !ok {
+			continue
+		}
+		lun := uint8(i)
+
+		reserve := func() (uint16, error) {
+			rsc := &ReserveDeviceSDRRepositoryCommand{RsLUN: lun}
+			if err := execute(rsc); err != nil {
+				// Reservation is optional for device SDRs, read without reservation
+				if e, ok := err.(*CommandError); ok && e.CompletionCode == CompletionInvalidCommand {
+					return 0, nil
+				}
+				return 0, err
+			}
+			return rsc.ReservationID, nil
+		}
+		get := func(gsc *GetSDRCommand) error {
+			gdc := &GetDeviceSDRCommand{RsLUN: lun, GetSDRCommand: *gsc}
+			if err := execute(gdc); err != nil {
+				return err
+			}
+			*gsc = gdc.GetSDRCommand
+			return nil
+		}
+
+		for retry := 0; ; retry++ {
+			before := &GetDeviceSDRInfoCommand{RsLUN: lun, SDRCount: true}
+			if err := execute(before); err != nil {
+				return nil, err
+			}
+
+			records, err := sdrGetRecords(c, reserve, get, int(before.Count), filter)
+			if err != nil {
+				return nil, err
+			}
+			if !before.DynamicPopulation {
+				sensors = append(sensors, records...)
+				break
+			}
+
+			after := &GetDeviceSDRInfoCommand{RsLUN: lun, SDRCount: true}
+			if err := execute(after); err != nil {
+				return nil, err
+			}
+			if after.PopulationChange == before.PopulationChange {
+				sensors = append(sensors, records...)
+				break
+			}
+			if retry >= sdrDevicePopulationRetries {
+				return nil, &MessageError{
+					Message: fmt.Sprintf("Sensor population of LUN %d changed while reading device SDRs", lun),
+					Detail:  after.String(),
+				}
+			}
+		}
+	}
+	return sensors, nil
+}
+
+// sdrGetRecords Reads records from the first to the last record ID, retries if the reservation is cancelled.
+func sdrGetRecords(c *Client, reserve func() (uint16, error), get sdrGetFunc, count int,
+	filter func(id uint16, t SDRType) bool) ([]SDR, error) {
+	sensors := make([]SDR, 0, count)
 
 retry:
-	rsc := &ReserveSDRRepositoryCommand{}
-	if err := c.Execute(rsc); err != nil {
+	reservation, err := reserve()
+	if err != nil {
 		return nil, err
 	}
-	reservation := rsc.ReservationID
 
 	var header *sdrHeader
 	var nextID uint16
-	var err error
 
 	for recordID := sdrFirstID; recordID != sdrLastID; {
 		if header == nil {
-			header, nextID, err = sdrGetRecordHeaderAndNextID(c, reservation, recordID)
+			header, nextID, err = sdrGetRecordHeaderAndNextID(get, reservation, recordID)
 			if err != nil {
 				if e, ok := err.(*CommandError); ok && e.CompletionCode == CompletionReservationCancelled {
+					sensors = sensors[:0]
 					goto retry
 				}
 				return nil, err
@@ -639,9 +1462,10 @@ retry:
 		}
 
 		if filter == nil || filter(header.RecordID, header.RecordType) {
-			record, err := sdrGetRecord(c, reservation, header)
+			record, err := sdrGetRecord(c, get, reservation, header)
 			if err != nil {
 				if e, ok := err.(*CommandError); ok && e.CompletionCode == CompletionReservationCancelled {
+					sensors = sensors[:0]
 					goto retry
 				}
 				return nil, err
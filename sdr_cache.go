@@ -0,0 +1,185 @@
+package ipmigo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SDRCache On-disk cache of SDR repository records per BMC.
+// The cache file is invalidated when the record count or the most recent addition / erase timestamp
+// of the repository changes (BMCs which do not update timestamps are detected only by the record count).
+type SDRCache struct {
+	Dir string // Directory of cache files
+
+	// Called if the cache file can not be written, the records read from SDR repository are returned anyway
+	WriteError func(err error)
+}
+
+type sdrCacheFile struct {
+	Key                string
+	SDRVersion         uint8
+	RecordCount        uint16
+	MostRecentAddition uint32
+	MostRecentErase    uint32
+	Records            [][]byte // Record header, key and body
+}
+
+// NewSDRCache Create a SDRCache storing files in the directory
+func NewSDRCache(dir string) *SDRCache {
+	return &SDRCache{Dir: dir}
+}
+
+// sdrCacheKey Returns the identity of the BMC
+func sdrCacheKey(c *Client) (string, error) {
+	gdi := &GetDeviceIDCommand{}
+	if err := c.Execute(gdi); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/%d/%d/%d.%d/%d/%d", c.args.Network, c.args.Address, gdi.DeviceID, gdi.DeviceRevision,
+		gdi.FirmwareMajorRevision, gdi.FirmwareMinorRevision, gdi.ManufacturerID, gdi.ProductID), nil
+}
+
+// path Returns the cache file path of the BMC identity
+func (s *SDRCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, "sdr-"+hex.EncodeToString(sum[:16])+".json")
+}
+
+// GetRecords Returns records from the cache file of the BMC without reading SDR repository,
+// or reads all records from SDR repository and writes the cache file if it is missing or outdated.
+// A write failure of the cache file is reported to WriteError and does not fail the call.
+// filter selects records to be returned, nil selects all.
+func (s *SDRCache) GetRecords(c *Client, filter func(id uint16, t SDRType) bool) ([]SDR, error) {
+	key, err := sdrCacheKey(c)
+	if err != nil {
+		return nil, err
+	}
+	gic := &GetSDRRepositoryInfoCommand{}
+	if err := c.Execute(gic); err != nil {
+		return nil, err
+	}
+
+	if records, ok := s.load(c.args, key, gic, filter); ok {
+		return records, nil
+	}
+
+	records, err := SDRGetAllRecordsRepo(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store(key, gic, records); err != nil && s.WriteError != nil {
+		s.WriteError(err)
+	}
+
+	if filter == nil {
+		return records, nil
+	}
+	filtered := make([]SDR, 0, len(records))
+	for _, r := range records {
+		if filter(r.ID(), r.Type()) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+// Invalidate Removes the cache file of the BMC
+func (s *SDRCache) Invalidate(c *Client) error {
+	key, err := sdrCacheKey(c)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// load Returns records of the cache file if it matches the BMC identity and the repository info
+func (s *SDRCache) load(args *Arguments, key string, gic *GetSDRRepositoryInfoCommand,
+	filter func(id uint16, t SDRType) bool) ([]SDR, bool) {
+	f, err := s.read(s.path(key))
+	if err != nil || f.Key != key || f.SDRVersion != gic.SDRVersion || f.RecordCount != gic.RecordCount ||
+		f.MostRecentAddition != gic.MostRecentAddition.Value || f.MostRecentErase != gic.MostRecentErase.Value {
+		return nil, false
+	}
+	records, err := sdrCacheRecords(args, f, filter)
+	if err != nil {
+		return nil, false
+	}
+	return records, true
+}
+
+// store Writes records to the cache file with the repository info
+func (s *SDRCache) store(key string, gic *GetSDRRepositoryInfoCommand, records []SDR) error {
+	f := &sdrCacheFile{
+		Key:                key,
+		SDRVersion:         gic.SDRVersion,
+		RecordCount:        gic.RecordCount,
+		MostRecentAddition: gic.MostRecentAddition.Value,
+		MostRecentErase:    gic.MostRecentErase.Value,
+		Records:            make([][]byte, 0, len(records)),
+	}
+	for _, r := range records {
+		f.Records = append(f.Records, sdrMarshalRecord(r))
+	}
+	return s.write(s.path(key), f)
+}
+
+func sdrCacheRecords(args *Arguments, f *sdrCacheFile, filter func(id uint16, t SDRType) bool) ([]SDR, error) {
+	records := make([]SDR, 0, len(f.Records))
+	for _, buf := range f.Records {
+		r, _, err := sdrUnmarshalRecord(args, buf)
+		if err != nil {
+			return nil, err
+		}
+		if filter == nil || filter(r.ID(), r.Type()) {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+func (s *SDRCache) read(path string) (*sdrCacheFile, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := &sdrCacheFile{}
+	if err := json.Unmarshal(buf, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// write Writes the cache file atomically
+func (s *SDRCache) write(path string, f *sdrCacheFile) error {
+	buf, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return &MessageError{Cause: err, Message: "Failed to create SDR cache directory"}
+	}
+
+	tmp, err := os.CreateTemp(s.Dir, ".sdr-*")
+	if err != nil {
+		return &MessageError{Cause: err, Message: "Failed to create SDR cache file"}
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return &MessageError{Cause: err, Message: "Failed to write SDR cache file"}
+	}
+	if err := tmp.Close(); err != nil {
+		return &MessageError{Cause: err, Message: "Failed to write SDR cache file"}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return &MessageError{Cause: err, Message: "Failed to write SDR cache file"}
+	}
+	return nil
+}
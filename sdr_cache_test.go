@@ -0,0 +1,89 @@
+package ipmigo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSDRCacheLoad(t *testing.T) {
+	records, err := SDRParseRecords([]byte{
+		0x01, 0x00, 0x51, 0xc0, 0x04, 0x01, 0x00, 0x00, 0xaa,
+		0x02, 0x00, 0x51, 0xc0, 0x04, 0x02, 0x00, 0x00, 0xbb,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSDRCache(t.TempDir())
+	info := func() *GetSDRRepositoryInfoCommand {
+		gic := &GetSDRRepositoryInfoCommand{SDRVersion: 0x51, RecordCount: 2}
+		gic.MostRecentAddition.Value = 0x5f000000
+		gic.MostRecentErase.Value = 0x5e000000
+		return gic
+	}
+	if _, ok := s.load(nil, "bmc", info(), nil); ok {
+		t.Fatal("load() without cache file = hit, want miss")
+	}
+	if err := s.store("bmc", info(), records); err != nil {
+		t.Fatal(err)
+	}
+
+	cached, ok := s.load(nil, "bmc", info(), nil)
+	if !ok {
+		t.Fatal("load() = miss, want hit")
+	}
+	if len(cached) != len(records) {
+		t.Fatalf("len(load()) = %d, want %d", len(cached), len(records))
+	}
+	for i, r := range cached {
+		if r.ID() != records[i].ID() || string(r.Data()) != string(records[i].Data()) {
+			t.Errorf("load()[%d] = %v, want %v", i, r, records[i])
+		}
+	}
+	if filtered, _ := s.load(nil, "bmc", info(), func(id uint16, t SDRType) bool { return id == 2 }); len(filtered) != 1 {
+		t.Errorf("len(load(filter)) = %d, want 1", len(filtered))
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		modify func(gic *GetSDRRepositoryInfoCommand)
+	}{
+		{"key", "other", func(gic *GetSDRRepositoryInfoCommand) {}},
+		{"count", "bmc", func(gic *GetSDRRepositoryInfoCommand) { gic.RecordCount++ }},
+		{"addition", "bmc", func(gic *GetSDRRepositoryInfoCommand) { gic.MostRecentAddition.Value++ }},
+		{"erase", "bmc", func(gic *GetSDRRepositoryInfoCommand) { gic.MostRecentErase.Value++ }},
+	}
+	for _, tt := range tests {
+		gic := info()
+		tt.modify(gic)
+		if _, ok := s.load(nil, tt.key, gic, nil); ok {
+			t.Errorf("%s: load() = hit, want miss", tt.name)
+		}
+	}
+
+	// Rewritten after the repository changed
+	gic := info()
+	gic.MostRecentAddition.Value++
+	if err := s.store("bmc", gic, records[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if cached, ok := s.load(nil, "bmc", gic, nil); !ok || len(cached) != 1 {
+		t.Errorf("load() after rewrite = %d records (hit %v), want 1 (hit)", len(cached), ok)
+	}
+	if _, ok := s.load(nil, "bmc", info(), nil); ok {
+		t.Error("load() with the old timestamps after rewrite = hit, want miss")
+	}
+}
+
+func TestSDRCacheWriteError(t *testing.T) {
+	s := NewSDRCache(filepath.Join(t.TempDir(), "file"))
+	if err := os.WriteFile(s.Dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Dir is a file, the cache file can not be written
+	if err := s.store("bmc", &GetSDRRepositoryInfoCommand{}, nil); err == nil {
+		t.Error("store() = nil, want error")
+	}
+}
@@ -0,0 +1,135 @@
+package ipmigo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SDRParseRecord Returns the record decoded from bytes of the record header, key and body,
+// and the bytes following the record.
+func SDRParseRecord(buf []byte) (SDR, []byte, error) {
+	return sdrUnmarshalRecord(nil, buf)
+}
+
+// SDRParseRecords Returns records decoded from concatenated raw records (header, key and body).
+func SDRParseRecords(buf []byte) ([]SDR, error) {
+	records := []SDR{}
+	for len(buf) > 0 {
+		r, rest, err := SDRParseRecord(buf)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+		buf = rest
+	}
+	return records, nil
+}
+
+// SDRMarshalRecord Returns raw bytes of the record (header, key and body).
+func SDRMarshalRecord(r SDR) []byte {
+	return sdrMarshalRecord(r)
+}
+
+// SDRWriteDump Writes records as concatenated raw records, the format of `ipmitool sdr dump`.
+func SDRWriteDump(w io.Writer, records []SDR) error {
+	bw := bufio.NewWriter(w)
+	for _, r := range records {
+		if _, err := bw.Write(sdrMarshalRecord(r)); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// SDRReadDump Returns records read from a dump, the format of `ipmitool sdr dump` (concatenated raw records)
+// or freeipmi SDR cache (file header followed by raw records).
+func SDRReadDump(r io.Reader) ([]SDR, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return sdrParseDump(buf)
+}
+
+const (
+	sdrFreeIPMICacheMagic      uint32 = 0xd00dbeef
+	sdrFreeIPMICacheHeaderSize        = 19
+)
+
+// sdrParseDump Returns records of the dump, a freeipmi SDR cache is detected by the magic number
+// of its file header (magic, file version, SDR version, record count, addition and erase timestamps).
+func sdrParseDump(buf []byte) ([]SDR, error) {
+	if len(buf) >= sdrFreeIPMICacheHeaderSize && (binary.LittleEndian.Uint32(buf) == sdrFreeIPMICacheMagic ||
+		binary.BigEndian.Uint32(buf) == sdrFreeIPMICacheMagic) {
+		count := int(binary.LittleEndian.Uint16(buf[9:11]))
+		records, _, err := sdrParseDumpRecords(buf[sdrFreeIPMICacheHeaderSize:], count)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) != count {
+			return nil, &MessageError{
+				Message: fmt.Sprintf("Invalid freeipmi SDR cache, record count : %d/%d", len(records), count),
+				Detail:  hex.EncodeToString(buf[:sdrFreeIPMICacheHeaderSize]),
+			}
+		}
+		// Bytes following the records (e.g. a trailer of newer file versions) are ignored
+		return records, nil
+	}
+
+	records, _, err := sdrParseDumpRecords(buf, -1)
+	return records, err
+}
+
+// sdrParseDumpRecords Returns up to max records (-1: all) and the remaining bytes, validates the SDR version
+// of each record header to reject unknown formats instead of misreading them as records.
+func sdrParseDumpRecords(buf []byte, max int) ([]SDR, []byte, error) {
+	records := []SDR{}
+	for len(buf) > 0 && (max < 0 || len(records) < max) {
+		if len(buf) < sdrHeaderSize {
+			return nil, nil, &MessageError{
+				Message: fmt.Sprintf("Invalid SDR dump, truncated record header : %d bytes", len(buf)),
+				Detail:  hex.EncodeToString(buf),
+			}
+		}
+		if v := buf[2]; v != 0x01 && v != 0x51 && v != 0x02 {
+			return nil, nil, &MessageError{
+				Message: fmt.Sprintf("Invalid SDR dump, unknown SDR version 0x%02x of record %d", v, len(records)),
+				Detail:  hex.EncodeToString(buf[:sdrHeaderSize]),
+			}
+		}
+
+		r, rest, err := SDRParseRecord(buf)
+		if err != nil {
+			return nil, nil, err
+		}
+		records = append(records, r)
+		buf = rest
+	}
+	return records, buf, nil
+}
+
+// SDRDumpFile Writes records to the file in the format of `ipmitool sdr dump`.
+func SDRDumpFile(path string, records []SDR) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := SDRWriteDump(f, records); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// SDRLoadFile Returns records of the file written by `ipmitool sdr dump`, SDRDumpFile or freeipmi SDR cache.
+func SDRLoadFile(path string) ([]SDR, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return sdrParseDump(buf)
+}
@@ -0,0 +1,119 @@
+package ipmigo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sdrDumpFixture = "testdata/ipmitool-sdr-dump.bin"
+
+func checkDumpFixtureRecords(t *testing.T, records []SDR) {
+	t.Helper()
+	if len(records) != 2 {
+		t.Fatalf("len(records) = %d, want 2", len(records))
+	}
+	full, ok := records[0].(*SDRFullSensor)
+	if !ok {
+		t.Fatalf("records[0] = %T, want *SDRFullSensor", records[0])
+	}
+	if id := full.SensorID(); id != "CPU Temp" {
+		t.Errorf("SensorID() = %q, want %q", id, "CPU Temp")
+	}
+	if full.ID() != 1 || full.SensorNumber != 0x01 || full.OwnerID != 0x20 {
+		t.Errorf("full sensor = %v", full)
+	}
+	nm, ok := records[1].(*SDRIntelNMDiscovery)
+	if !ok {
+		t.Fatalf("records[1] = %T, want *SDRIntelNMDiscovery", records[1])
+	}
+	if nm.ID() != 2 || nm.SlaveAddress != 0x2c || nm.HealthEventSensor != 0x30 {
+		t.Errorf("NM discovery = %v", nm)
+	}
+}
+
+func TestSDRLoadFileIpmitool(t *testing.T) {
+	records, err := SDRLoadFile(sdrDumpFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDumpFixtureRecords(t, records)
+}
+
+func TestSDRDumpRoundTrip(t *testing.T) {
+	fixture, err := os.ReadFile(sdrDumpFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := SDRParseRecords(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range records {
+		if _, rest, err := SDRParseRecord(SDRMarshalRecord(r)); err != nil || len(rest) != 0 {
+			t.Errorf("records[%d]: SDRParseRecord(SDRMarshalRecord()) = %v, rest %x", i, err, rest)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := SDRWriteDump(buf, records); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), fixture) {
+		t.Errorf("SDRWriteDump() = %x, want %x", buf.Bytes(), fixture)
+	}
+	loaded, err := SDRReadDump(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDumpFixtureRecords(t, loaded)
+
+	path := filepath.Join(t.TempDir(), "sdr.bin")
+	if err := SDRDumpFile(path, loaded); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err = SDRLoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	checkDumpFixtureRecords(t, loaded)
+}
+
+func TestSDRReadDumpFreeIPMI(t *testing.T) {
+	fixture, err := os.ReadFile(sdrDumpFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := make([]byte, sdrFreeIPMICacheHeaderSize)
+	binary.LittleEndian.PutUint32(header[0:4], sdrFreeIPMICacheMagic)
+	binary.LittleEndian.PutUint32(header[4:8], 0x00000001)
+	header[8] = 0x51
+	binary.LittleEndian.PutUint16(header[9:11], 2)
+	binary.LittleEndian.PutUint32(header[11:15], 0x5f000000)
+	binary.LittleEndian.PutUint32(header[15:19], 0x5e000000)
+	trailer := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	buf := append(append(header, fixture...), trailer...)
+	records, err := SDRReadDump(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDumpFixtureRecords(t, records)
+
+	// Record count of the header is more than the records
+	binary.LittleEndian.PutUint16(buf[9:11], 3)
+	if _, err := SDRReadDump(bytes.NewReader(buf[:len(header)+len(fixture)])); err == nil {
+		t.Error("SDRReadDump() with missing records = nil, want error")
+	}
+}
+
+func TestSDRReadDumpUnknown(t *testing.T) {
+	if _, err := SDRReadDump(bytes.NewReader([]byte{0x01, 0x00, 0x99, 0x01, 0x00})); err == nil {
+		t.Error("SDRReadDump() with unknown SDR version = nil, want error")
+	}
+	if _, err := SDRReadDump(bytes.NewReader([]byte{0x01, 0x00, 0x51})); err == nil {
+		t.Error("SDRReadDump() with truncated header = nil, want error")
+	}
+}
@@ -0,0 +1,119 @@
+package ipmigo
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	sdrOEMMinSize = 3
+
+	// IANA Private Enterprise Numbers
+	ManufacturerIDIntel uint32 = 0x000157
+)
+
+// SDROEM OEM Record (Section 43.12)
+type SDROEM struct {
+	header *sdrHeader
+	data   []byte
+
+	ManufacturerID uint32 // IANA Private Enterprise Number
+	OEMData        []byte
+}
+
+func (r *SDROEM) Type() SDRType            { return r.header.RecordType }
+func (r *SDROEM) ID() uint16               { return r.header.RecordID }
+func (r *SDROEM) recordHeader() *sdrHeader { return r.header }
+func (r *SDROEM) Data() []byte             { return r.data }
+func (r *SDROEM) String() string           { return toJSON(r) }
+
+func (r *SDROEM) Unmarshal(buf []byte) ([]byte, error) {
+	if l := len(buf); l < sdrOEMMinSize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid SDROEM size : %d/%d", l, sdrOEMMinSize),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	r.data = buf
+	r.ManufacturerID = uint32(buf[0]) | uint32(buf[1])<<8 | uint32(buf[2])<<16
+	r.OEMData = buf[3:]
+	return nil, nil
+}
+
+// SDROEMDecoder Decodes an OEM record of a manufacturer,
+// returns `nil` SDR if the record is not known to the decoder.
+// The record is returned as SDROEM if the decoder returns an error.
+type SDROEMDecoder func(r *SDROEM) (SDR, error)
+
+var sdrOEMDecoders = map[uint32]SDROEMDecoder{
+	ManufacturerIDIntel: sdrDecodeIntelOEM,
+}
+
+// SDRRegisterOEMDecoder Registers the decoder of OEM records for the manufacturer IANA ID,
+// replaces the previous one. OEM records without a decoder are returned as SDROEM.
+// Not safe for concurrent use with reading SDR records.
+func SDRRegisterOEMDecoder(manufacturerID uint32, d SDROEMDecoder) {
+	if d == nil {
+		delete(sdrOEMDecoders, manufacturerID)
+		return
+	}
+	sdrOEMDecoders[manufacturerID] = d
+}
+
+// sdrDecodeOEM Returns the record decoded by the registered decoder, otherwise r.
+// A malformed OEM record is returned as r to not fail reading the other records.
+func sdrDecodeOEM(r *SDROEM) SDR {
+	if d, ok := sdrOEMDecoders[r.ManufacturerID]; ok {
+		if record, err := d(r); err == nil && record != nil {
+			return record
+		}
+	}
+	return r
+}
+
+// SDRIntelNMDiscovery Intel Node Manager Discovery OEM Record (Intel Node Manager specification)
+// locates the Node Manager controller and its sensors.
+type SDRIntelNMDiscovery struct {
+	*SDROEM
+
+	Version                       uint8
+	SlaveAddress                  uint8 // Node Manager controller (7-bit)
+	OwnerLUN                      uint8
+	ChannelNumber                 uint8
+	HealthEventSensor             uint8
+	ExceptionEventSensor          uint8
+	OperationalCapabilitiesSensor uint8
+	AlertThresholdExceededSensor  uint8
+}
+
+const (
+	sdrIntelNMDiscoverySubtype = 0x0d
+	sdrIntelNMDiscoverySize    = 8
+)
+
+func (r *SDRIntelNMDiscovery) String() string { return toJSON(r) }
+
+func sdrDecodeIntelOEM(r *SDROEM) (SDR, error) {
+	if len(r.OEMData) == 0 || r.OEMData[0] != sdrIntelNMDiscoverySubtype {
+		return nil, nil
+	}
+
+	buf := r.OEMData
+	if l := len(buf); l < sdrIntelNMDiscoverySize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid SDRIntelNMDiscovery size : %d/%d", l, sdrIntelNMDiscoverySize),
+			Detail:  hex.EncodeToString(r.data),
+		}
+	}
+	return &SDRIntelNMDiscovery{
+		SDROEM:                        r,
+		Version:                       buf[1],
+		SlaveAddress:                  buf[2] & 0xfe >> 1,
+		OwnerLUN:                      buf[3] & 0x03,
+		ChannelNumber:                 buf[3] & 0xf0 >> 4,
+		HealthEventSensor:             buf[4],
+		ExceptionEventSensor:          buf[5],
+		OperationalCapabilitiesSensor: buf[6],
+		AlertThresholdExceededSensor:  buf[7],
+	}, nil
+}
@@ -0,0 +1,131 @@
+package ipmigo
+
+import (
+	"testing"
+)
+
+func TestSDRGetRecordsReservationCancelled(t *testing.T) {
+	repo := map[uint16][]byte{
+		1: {0x01, 0x00, 0x51, 0xc0, 0x04, 0x01, 0x00, 0x00, 0xaa},
+		2: {0x02, 0x00, 0x51, 0xc0, 0x04, 0x01, 0x00, 0x00, 0xbb},
+	}
+	next := map[uint16]uint16{1: 2, 2: sdrLastID}
+
+	reservations := 0
+	reserve := func() (uint16, error) {
+		reservations++
+		return uint16(reservations), nil
+	}
+	cancelled := false
+	get := func(gsc *GetSDRCommand) error {
+		id := gsc.RecordID
+		if id == sdrFirstID {
+			id = 1
+		}
+		// Cancel the first reservation while reading the second record
+		if id == 2 && !cancelled {
+			cancelled = true
+			return &CommandError{CompletionCode: CompletionReservationCancelled, Command: gsc}
+		}
+		data := repo[id][gsc.RecordOffset:]
+		if len(data) > int(gsc.ReadBytes) {
+			data = data[:gsc.ReadBytes]
+		}
+		gsc.NextRecordID = next[id]
+		gsc.RecordData = data
+		return nil
+	}
+
+	c := &Client{sdrReadingBytes: sdrDefaultReadBytes}
+	records, err := sdrGetRecords(c, reserve, get, len(repo), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reservations != 2 {
+		t.Errorf("reservations = %d, want 2", reservations)
+	}
+	if len(records) != 2 {
+		t.Fatalf("len(records) = %d, want 2", len(records))
+	}
+	for i, r := range records {
+		if want := uint16(i + 1); r.ID() != want {
+			t.Errorf("records[%d].ID() = %d, want %d", i, r.ID(), want)
+		}
+	}
+}
+
+func TestSDRGetRecordsDevice(t *testing.T) {
+	// Device SDRs of each LUN (record ID -> header, key and body)
+	luns := map[uint8]map[uint16][]byte{
+		0: {
+			1: {0x01, 0x00, 0x51, 0xc0, 0x04, 0x01, 0x00, 0x00, 0xaa},
+		},
+		1: {
+			1: {0x01, 0x00, 0x51, 0xc0, 0x04, 0x01, 0x00, 0x00, 0xbb},
+			2: {0x02, 0x00, 0x51, 0xc0, 0x04, 0x01, 0x00, 0x00, 0xcc},
+		},
+	}
+	next := map[uint16]uint16{1: 2, 2: sdrLastID}
+
+	infos := map[uint8]int{}
+	walks := map[uint8]int{}
+	execute := func(cmd Command) error {
+		switch cmd := cmd.(type) {
+		case *GetDeviceSDRInfoCommand:
+			lun := cmd.RsLUN
+			infos[lun]++
+			cmd.Count = uint8(len(luns[lun]))
+			cmd.DynamicPopulation = true
+			cmd.LUNHasSensors = [4]bool{true, true, false, false}
+			// Population of LUN 1 changes while the first walk
+			cmd.PopulationChange = 0x100
+			if lun == 1 && infos[lun] >= 2 {
+				cmd.PopulationChange = 0x200
+			}
+			return nil
+		case *ReserveDeviceSDRRepositoryCommand:
+			walks[cmd.RsLUN]++
+			return &CommandError{CompletionCode: CompletionInvalidCommand, Command: cmd}
+		case *GetDeviceSDRCommand:
+			if cmd.ReservationID != 0 {
+				t.Errorf("ReservationID = %d, want 0", cmd.ReservationID)
+			}
+			repo := luns[cmd.RsLUN]
+			id := cmd.RecordID
+			if id == sdrFirstID {
+				id = 1
+			}
+			data := repo[id][cmd.RecordOffset:]
+			if len(data) > int(cmd.ReadBytes) {
+				data = data[:cmd.ReadBytes]
+			}
+			cmd.NextRecordID = next[id]
+			if _, ok := repo[next[id]]; !ok {
+				cmd.NextRecordID = sdrLastID
+			}
+			cmd.RecordData = data
+			return nil
+		}
+		t.Fatalf("Unexpected command %s", cmd.Name())
+		return nil
+	}
+
+	c := &Client{sdrReadingBytes: sdrDefaultReadBytes}
+	records, err := sdrGetRecordsDevice(c, execute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if walks[0] != 1 || walks[1] != 2 {
+		t.Errorf("walks = %v, want LUN 0: 1, LUN 1: 2", walks)
+	}
+
+	want := []byte{0xaa, 0xbb, 0xcc}
+	if len(records) != len(want) {
+		t.Fatalf("len(records) = %d, want %d", len(records), len(want))
+	}
+	for i, r := range records {
+		if data := r.Data(); data[len(data)-1] != want[i] {
+			t.Errorf("records[%d].Data() = %x, want last byte %02x", i, data, want[i])
+		}
+	}
+}
@@ -103,9 +103,7 @@ func (r *SELEventRecord) Description() string {
 	switch t := r.EventType; {
 	case t.IsGeneric() || t.IsThreshold():
 		f = func() (string, bool) {
-			offset := r.EventData1 & 0x0f
-			desc, ok := sensorGenericEventDesc[uint32(r.EventType)<<8|uint32(offset)]
-			return desc, ok
+			return eventDescription(r.EventType, r.SensorType, r.EventData1&0x0f, 0xff, 0xff)
 		}
 	case t.IsSensorSpecific():
 		f = func() (string, bool) {
@@ -117,18 +115,7 @@ func (r *SELEventRecord) Description() string {
 			if r.EventData1&0x30 != 0 {
 				d3 = r.EventData3
 			}
-
-			offset := r.EventData1 & 0x0f
-			for {
-				// First, try to get a more detailed definition
-				desc, ok := sensorSpecificEventDesc[uint32(r.SensorType)<<24|uint32(offset)<<16|uint32(d2)<<8|uint32(d3)]
-				if !ok && (d2 != 0xff || d3 != 0xff) {
-					// If not found, get a general definition
-					d2, d3 = 0xff, 0xff
-					continue
-				}
-				return desc, ok
-			}
+			return eventDescription(r.EventType, r.SensorType, r.EventData1&0x0f, d2, d3)
 		}
 	case t.IsOEM():
 		f = func() (string, bool) {
@@ -147,6 +134,48 @@ func (r *SELEventRecord) Description() string {
 	}
 }
 
+// SensorRecord Returns the record of the sensor that generated the event (e.g. from SDRGetAllRecordsRepo).
+func (r *SELEventRecord) SensorRecord(records []SDR) (SDR, bool) {
+	owner, lun := uint8(r.GeneratorID), uint8(r.GeneratorID>>8)&0x03
+	for _, record := range records {
+		var id, l, number, count uint8
+		switch s := record.(type) {
+		case *SDRFullSensor:
+			id, l, number, count = s.OwnerID, s.OwnerLUN, s.SensorNumber, 1
+		case *SDRCompactSensor:
+			id, l, number, count = s.OwnerID, s.OwnerLUN, s.SensorNumber, s.Share.Count
+		case *SDREventOnlySensor:
+			id, l, number, count = s.OwnerID, s.OwnerLUN, s.SensorNumber, s.Share.Count
+		default:
+			continue
+		}
+		if count == 0 {
+			count = 1
+		}
+		if id == owner && l == lun && r.SensorNumber >= number && int(r.SensorNumber) < int(number)+int(count) {
+			return record, true
+		}
+	}
+	return nil, false
+}
+
+// SensorName Returns the ID string of the sensor that generated the event.
+func (r *SELEventRecord) SensorName(records []SDR) (string, bool) {
+	record, ok := r.SensorRecord(records)
+	if !ok {
+		return "", false
+	}
+	switch s := record.(type) {
+	case *SDRFullSensor:
+		return s.SensorID(), true
+	case *SDRCompactSensor:
+		return s.SensorID(), true
+	case *SDREventOnlySensor:
+		return s.SensorID(), true
+	}
+	return "", false
+}
+
 // SELTimestampedOEMRecord Timestamped OEM SEL record (Section 32.2)
 type SELTimestampedOEMRecord struct {
 	data []byte
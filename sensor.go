@@ -1,7 +1,13 @@
 package ipmigo
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math"
+)
+
+const (
+	sensorFactorsSize = 6
 )
 
 type ThresholdStatus string
@@ -42,6 +48,122 @@ func NewThresholdStatus(status uint8) ThresholdStatus {
 	}
 }
 
+// ThresholdMask Bit mask of thresholds (Section 35.8, 35.9)
+type ThresholdMask uint8
+
+const (
+	ThresholdMaskLNC ThresholdMask = 0x01 // Lower Non-Critical
+	ThresholdMaskLCR ThresholdMask = 0x02 // Lower Critical
+	ThresholdMaskLNR ThresholdMask = 0x04 // Lower Non-Recoverable
+	ThresholdMaskUNC ThresholdMask = 0x08 // Upper Non-Critical
+	ThresholdMaskUCR ThresholdMask = 0x10 // Upper Critical
+	ThresholdMaskUNR ThresholdMask = 0x20 // Upper Non-Recoverable
+)
+
+// Has Returns `true` if all bits of m are set.
+func (t ThresholdMask) Has(m ThresholdMask) bool { return t&m == m }
+
+// EventMask Bit mask of assertion/deassertion events (Table 43-1, Section 35.10 - 35.12).
+// Threshold-base sensors use EventMaskXXX bits, discrete sensors use one bit per state offset.
+type EventMask uint16
+
+const (
+	EventMaskLNCLow  EventMask = 0x0001 // Lower Non-Critical going low
+	EventMaskLNCHigh EventMask = 0x0002 // Lower Non-Critical going high
+	EventMaskLCRLow  EventMask = 0x0004 // Lower Critical going low
+	EventMaskLCRHigh EventMask = 0x0008 // Lower Critical going high
+	EventMaskLNRLow  EventMask = 0x0010 // Lower Non-Recoverable going low
+	EventMaskLNRHigh EventMask = 0x0020 // Lower Non-Recoverable going high
+	EventMaskUNCLow  EventMask = 0x0040 // Upper Non-Critical going low
+	EventMaskUNCHigh EventMask = 0x0080 // Upper Non-Critical going high
+	EventMaskUCRLow  EventMask = 0x0100 // Upper Critical going low
+	EventMaskUCRHigh EventMask = 0x0200 // Upper Critical going high
+	EventMaskUNRLow  EventMask = 0x0400 // Upper Non-Recoverable going low
+	EventMaskUNRHigh EventMask = 0x0800 // Upper Non-Recoverable going high
+
+	eventMaskThreshold EventMask = 0x0fff
+	eventMaskDiscrete  EventMask = 0x7fff
+)
+
+// NewEventMaskOffsets Returns the mask of discrete state offsets (0-14).
+func NewEventMaskOffsets(offsets ...uint8) EventMask {
+	var m EventMask
+	for _, o := range offsets {
+		if o < 15 {
+			m |= 1 << o
+		}
+	}
+	return m
+}
+
+// Has Returns `true` if all bits of m are set.
+func (e EventMask) Has(m EventMask) bool { return e&m == m }
+
+// Offsets Returns the state offsets of the set bits.
+func (e EventMask) Offsets() []uint8 {
+	offsets := []uint8{}
+	for i := uint8(0); i < 15; i++ {
+		if e&(1<<i) != 0 {
+			offsets = append(offsets, i)
+		}
+	}
+	return offsets
+}
+
+func (e EventMask) marshal() []byte {
+	return []byte{byte(e), byte(e >> 8)}
+}
+
+// SensorFactors Reading conversion factors (Table 43-1 bytes 25-30, Section 35.5)
+type SensorFactors struct {
+	M           int16
+	Tolerance   uint8 // in +/- 1/2 raw counts
+	B           int16
+	Accuracy    uint16 // in 1/100 percent scaled up by AccuracyExp
+	AccuracyExp uint8
+	RExp        int8
+	BExp        int8
+}
+
+func (f *SensorFactors) Unmarshal(buf []byte) ([]byte, error) {
+	if l := len(buf); l < sensorFactorsSize {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid SensorFactors size : %d/%d", l, sensorFactorsSize),
+			Detail:  hex.EncodeToString(buf),
+		}
+	}
+	f.M = tos16(uint16(buf[0])|uint16(buf[1]&0xc0)<<2, 10)
+	f.Tolerance = buf[1] & 0x3f
+	f.B = tos16(uint16(buf[2])|uint16(buf[3]&0xc0)<<2, 10)
+	f.Accuracy = uint16(buf[3]&0x3f) | uint16(buf[4]&0xf0)<<2
+	f.AccuracyExp = buf[4] & 0x0c >> 2
+	f.RExp = int8(tos16(uint16(buf[5]&0xf0)>>4, 4))
+	f.BExp = int8(tos16(uint16(buf[5]&0x0f), 4))
+	return buf[sensorFactorsSize:], nil
+}
+
+// Convert Returns the reading converted by the linear formula (Section 36.3).
+// analog is the analog data format of the sensor units (0: unsigned, 1: 1's complement, 2: 2's complement).
+func (f *SensorFactors) Convert(analog, value uint8) float64 {
+	switch analog {
+	// unsigned
+	case 0:
+		return (float64(int(f.M)*int(value)) + float64(f.B)*math.Pow10(int(f.BExp))) * math.Pow10(int(f.RExp))
+	// 1's complement
+	case 1:
+		if value&0x80 != 0 {
+			value += 1
+		}
+		fallthrough
+	// 2's complement
+	case 2:
+		return (float64(int(f.M)*int(int8(value))) + float64(f.B)*math.Pow10(int(f.BExp))) * math.Pow10(int(f.RExp))
+	default:
+		// Not analog sensor
+		return 0.0
+	}
+}
+
 // SensorType Sensor Type (Table 42-3)
 type SensorType uint8
 
@@ -0,0 +1,282 @@
+package ipmigo
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// SensorEventKind Kind of SensorEvent
+type SensorEventKind uint8
+
+const (
+	SensorEventStatus SensorEventKind = iota // Threshold status changed
+	SensorEventStates                        // Asserted discrete states changed
+	SensorEventDelta                         // Reading moved by more than Delta
+	SensorEventError                         // Sensor could not be read
+)
+
+func (k SensorEventKind) String() string {
+	switch k {
+	case SensorEventStatus:
+		return "status"
+	case SensorEventStates:
+		return "states"
+	case SensorEventDelta:
+		return "delta"
+	case SensorEventError:
+		return "error"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(k))
+	}
+}
+
+// SensorEvent Change of a watched sensor
+type SensorEvent struct {
+	Kind     SensorEventKind
+	Time     time.Time
+	Record   SDR     `json:"-"` // Record of the sensor
+	Number   uint8   // Sensor number
+	Name     string  // Sensor name
+	Sensor   *Sensor // Current reading, nil for SensorEventError
+	Previous *Sensor // Reading at the last event of the same kind or the first poll, nil for SensorEventError
+	Err      error   // Only valid for SensorEventError
+}
+
+func (e *SensorEvent) String() string {
+	type event SensorEvent
+	v := struct {
+		*event
+		Err string `json:",omitempty"`
+	}{event: (*event)(e)}
+	if e.Err != nil {
+		v.Err = e.Err.Error()
+	}
+	return toJSON(v)
+}
+
+// SensorWatcher Polls sensors over one Client and emits events on changes.
+// The first poll establishes the baseline and emits no events.
+type SensorWatcher struct {
+	Client   *Client
+	Records  []SDR         // Watched sensor records (full, compact or event-only)
+	Interval time.Duration // Polling interval (The default is 10sec)
+
+	// Number of consecutive polls a new status or states must be observed before emitting (The default is 1)
+	Debounce int
+	// Value the reading must move past the threshold before returning to a less severe status, 0 disables
+	Hysteresis float64
+	// Min change of the analog reading value to emit SensorEventDelta, 0 disables
+	Delta float64
+
+	mu     sync.Mutex // Guards Start and Stop
+	events chan *SensorEvent
+	done   chan struct{}
+	stop   chan struct{}
+	states map[sensorWatchKey]*sensorWatchState
+}
+
+// sensorWatchKey Sensor of a record, shared records have several sensors
+type sensorWatchKey struct {
+	record SDR
+	number uint8
+}
+
+type sensorWatchState struct {
+	status *Sensor // Last reported status / states
+	value  *Sensor // Last reported value
+	next   *Sensor // Candidate status / states waiting for debounce
+	count  int
+}
+
+// NewSensorWatcher Create a SensorWatcher with default settings
+func NewSensorWatcher(c *Client, records []SDR) *SensorWatcher {
+	return &SensorWatcher{
+		Client:   c,
+		Records:  records,
+		Interval: 10 * time.Second,
+		Debounce: 1,
+	}
+}
+
+// Start Starts polling and returns the event channel, it is closed by Stop.
+// Calling it again before Stop returns the same channel.
+func (w *SensorWatcher) Start() <-chan *SensorEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.events != nil {
+		return w.events
+	}
+	w.events = make(chan *SensorEvent, len(w.Records)+1)
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	w.states = make(map[sensorWatchKey]*sensorWatchState, len(w.Records))
+
+	go w.run()
+	return w.events
+}
+
+// Stop Stops polling and closes the event channel, calling it again has no effect.
+// Start and Stop can be called from different goroutines.
+func (w *SensorWatcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.events == nil {
+		return
+	}
+	close(w.stop)
+	<-w.done
+	close(w.events)
+	w.events = nil
+}
+
+func (w *SensorWatcher) run() {
+	defer close(w.done)
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if !w.poll() {
+			return
+		}
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll Reads all watched sensors, returns `false` if stopped
+func (w *SensorWatcher) poll() bool {
+	for _, r := range w.Records {
+		select {
+		case <-w.stop:
+			return false
+		default:
+		}
+
+		for _, s := range newSensors(r) {
+			if err := s.readRecord(w.Client); err != nil {
+				e := &SensorEvent{Kind: SensorEventError, Time: time.Now(), Record: r, Number: s.Number, Name: s.Name, Err: err}
+				if !w.emit(e) {
+					return false
+				}
+				continue
+			}
+			for _, e := range w.update(r, s) {
+				if !w.emit(e) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (w *SensorWatcher) emit(e *SensorEvent) bool {
+	select {
+	case w.events <- e:
+		return true
+	case <-w.stop:
+		return false
+	}
+}
+
+// update Compares the reading with the last reported one and returns events
+func (w *SensorWatcher) update(r SDR, s *Sensor) []*SensorEvent {
+	key := sensorWatchKey{record: r, number: s.Number}
+	st, ok := w.states[key]
+	if !ok {
+		w.states[key] = &sensorWatchState{status: s, value: s}
+		return nil
+	}
+
+	var events []*SensorEvent
+	now := time.Now()
+
+	if w.changed(st.status, s) {
+		if st.next != nil && !w.changed(st.next, s) {
+			st.count++
+		} else {
+			st.next, st.count = s, 1
+		}
+		if st.count >= w.Debounce {
+			kind := SensorEventStates
+			if s.EventType.IsThreshold() {
+				kind = SensorEventStatus
+			}
+			events = append(events, &SensorEvent{Kind: kind, Time: now, Record: r, Number: s.Number, Name: s.Name,
+				Sensor: s, Previous: st.status})
+			st.status, st.next, st.count = s, nil, 0
+		}
+	} else {
+		st.next, st.count = nil, 0
+	}
+
+	if w.Delta > 0 && s.Available && s.Analog && st.value.Available &&
+		math.Abs(s.Value-st.value.Value) > w.Delta {
+		events = append(events, &SensorEvent{Kind: SensorEventDelta, Time: now, Record: r, Number: s.Number, Name: s.Name,
+			Sensor: s, Previous: st.value})
+		st.value = s
+	} else if !st.value.Available {
+		st.value = s
+	}
+
+	return events
+}
+
+// changed Returns `true` if status or states of s differs from the reported one
+func (w *SensorWatcher) changed(reported, s *Sensor) bool {
+	if reported.Available != s.Available {
+		return true
+	}
+	if !s.EventType.IsThreshold() {
+		return sensorStatesMask(reported.States) != sensorStatesMask(s.States)
+	}
+	if reported.Status == s.Status {
+		return false
+	}
+
+	// Returning to a less severe status requires the reading to move past the threshold by Hysteresis
+	if w.Hysteresis > 0 && s.Analog && thresholdSeverity(s.Status) < thresholdSeverity(reported.Status) {
+		if t, ok := reported.Thresholds[reported.Status]; ok {
+			switch reported.Status {
+			case ThresholdStatusLNC, ThresholdStatusLCR, ThresholdStatusLNR:
+				return s.Value >= t+w.Hysteresis
+			default:
+				return s.Value <= t-w.Hysteresis
+			}
+		}
+	}
+	return true
+}
+
+func thresholdSeverity(s ThresholdStatus) int {
+	switch s {
+	case ThresholdStatusLNC, ThresholdStatusUNC:
+		return 1
+	case ThresholdStatusLCR, ThresholdStatusUCR:
+		return 2
+	case ThresholdStatusLNR, ThresholdStatusUNR:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func sensorStatesMask(states SensorStates) EventMask {
+	var m EventMask
+	for _, s := range states {
+		m |= NewEventMaskOffsets(s.Offset)
+	}
+	return m
+}
@@ -0,0 +1,118 @@
+package ipmigo
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func thresholdSensor(status ThresholdStatus, value float64) *Sensor {
+	return &Sensor{
+		EventType:  EventType(0x01),
+		Available:  true,
+		Analog:     true,
+		Value:      value,
+		Status:     status,
+		Thresholds: map[ThresholdStatus]float64{ThresholdStatusUNC: 80, ThresholdStatusUCR: 90},
+	}
+}
+
+func discreteSensor(offsets ...uint8) *Sensor {
+	s := &Sensor{EventType: EventType(0x6f), Available: true}
+	for _, o := range offsets {
+		s.States = append(s.States, SensorState{Offset: o})
+	}
+	return s
+}
+
+func TestSensorWatcherUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		watcher *SensorWatcher
+		polls   []*Sensor
+		events  [][]SensorEventKind // Events of each poll after the baseline
+	}{
+		{
+			name:    "status",
+			watcher: &SensorWatcher{Debounce: 1},
+			polls: []*Sensor{
+				thresholdSensor(ThresholdStatusOK, 70),
+				thresholdSensor(ThresholdStatusUNC, 81),
+				thresholdSensor(ThresholdStatusUNC, 82),
+			},
+			events: [][]SensorEventKind{{SensorEventStatus}, nil},
+		},
+		{
+			name:    "debounce",
+			watcher: &SensorWatcher{Debounce: 2},
+			polls: []*Sensor{
+				thresholdSensor(ThresholdStatusOK, 70),
+				thresholdSensor(ThresholdStatusUNC, 81),
+				thresholdSensor(ThresholdStatusOK, 79),
+				thresholdSensor(ThresholdStatusUNC, 81),
+				thresholdSensor(ThresholdStatusUNC, 81),
+			},
+			events: [][]SensorEventKind{nil, nil, nil, {SensorEventStatus}},
+		},
+		{
+			name:    "hysteresis",
+			watcher: &SensorWatcher{Debounce: 1, Hysteresis: 2},
+			polls: []*Sensor{
+				thresholdSensor(ThresholdStatusOK, 70),
+				thresholdSensor(ThresholdStatusUCR, 91),
+				thresholdSensor(ThresholdStatusUNC, 89),
+				thresholdSensor(ThresholdStatusUNC, 87),
+				thresholdSensor(ThresholdStatusUCR, 90),
+			},
+			events: [][]SensorEventKind{{SensorEventStatus}, nil, {SensorEventStatus}, {SensorEventStatus}},
+		},
+		{
+			name:    "delta",
+			watcher: &SensorWatcher{Debounce: 1, Delta: 5},
+			polls: []*Sensor{
+				thresholdSensor(ThresholdStatusOK, 50),
+				thresholdSensor(ThresholdStatusOK, 54),
+				thresholdSensor(ThresholdStatusOK, 56),
+				thresholdSensor(ThresholdStatusOK, 60),
+			},
+			events: [][]SensorEventKind{nil, {SensorEventDelta}, nil},
+		},
+		{
+			name:    "states",
+			watcher: &SensorWatcher{Debounce: 1},
+			polls: []*Sensor{
+				discreteSensor(0),
+				discreteSensor(0),
+				discreteSensor(0, 1),
+				discreteSensor(1, 0),
+			},
+			events: [][]SensorEventKind{nil, {SensorEventStates}, nil},
+		},
+	}
+
+	r := &sdrRaw{header: &sdrHeader{RecordType: SDRTypeFullSensor}}
+	for _, tt := range tests {
+		w := tt.watcher
+		w.states = make(map[sensorWatchKey]*sensorWatchState)
+		if events := w.update(r, tt.polls[0]); events != nil {
+			t.Errorf("%s: baseline events = %v, want none", tt.name, events)
+		}
+		for i, s := range tt.polls[1:] {
+			var kinds []SensorEventKind
+			for _, e := range w.update(r, s) {
+				kinds = append(kinds, e.Kind)
+			}
+			if !reflect.DeepEqual(kinds, tt.events[i]) {
+				t.Errorf("%s: poll %d events = %v, want %v", tt.name, i+1, kinds, tt.events[i])
+			}
+		}
+	}
+}
+
+func TestSensorEventString(t *testing.T) {
+	e := &SensorEvent{Kind: SensorEventError, Name: "CPU1", Err: errors.New("timeout")}
+	if s := e.String(); !strings.Contains(s, `"Err":"timeout"`) {
+		t.Errorf("String() = %s, want Err as string", s)
+	}
+}
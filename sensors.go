@@ -0,0 +1,252 @@
+package ipmigo
+
+import (
+	"context"
+)
+
+// Sensor Sensor record with its current reading
+type Sensor struct {
+	Record SDR
+
+	Name           string
+	Number         uint8
+	OwnerID        uint8
+	OwnerLUN       uint8
+	ChannelNumber  uint8
+	EntityID       uint8
+	EntityInstance uint8
+	SensorType     SensorType
+	EventType      EventType
+
+	Available  bool // `false` if reading is unavailable, scanning is disabled or there is no reading (event-only)
+	RawReading uint8
+	Analog     bool    // `true` if Value is valid
+	Value      float64 // Reading in engineering units
+	Unit       string
+
+	Status     ThresholdStatus             // Threshold status of threshold-base sensor
+	Thresholds map[ThresholdStatus]float64 // Readable thresholds of threshold-base full sensor
+	States     SensorStates                // Asserted states of discrete sensor
+}
+
+func (s *Sensor) String() string { return toJSON(s) }
+
+func (s *Sensor) setCommon(r *SDRCommonSensor) {
+	s.Number = r.SensorNumber
+	s.OwnerID = r.OwnerID
+	s.OwnerLUN = r.OwnerLUN
+	s.ChannelNumber = r.ChannelNumber
+	s.EntityID = r.Entity.ID
+	s.EntityInstance = r.Entity.Instance
+	s.SensorType = r.SensorType
+	s.EventType = EventType(r.EventReadingType)
+}
+
+var sensorThresholds = []struct {
+	mask   ThresholdMask
+	status ThresholdStatus
+}{
+	{ThresholdMaskLNR, ThresholdStatusLNR},
+	{ThresholdMaskLCR, ThresholdStatusLCR},
+	{ThresholdMaskLNC, ThresholdStatusLNC},
+	{ThresholdMaskUNC, ThresholdStatusUNC},
+	{ThresholdMaskUCR, ThresholdStatusUCR},
+	{ThresholdMaskUNR, ThresholdStatusUNR},
+}
+
+// read Gets the sensor reading, the sensor is left unavailable if BMC returns an error completion code
+func (s *Sensor) read(c *Client) (*GetSensorReadingCommand, error) {
+	gsr := &GetSensorReadingCommand{RsLUN: s.OwnerLUN, SensorNumber: s.Number}
+	if err := sensorExecute(c, s.OwnerID, s.ChannelNumber, gsr); err != nil {
+		if _, ok := err.(*CommandError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !gsr.IsValid() {
+		return nil, nil
+	}
+
+	s.Available = true
+	s.RawReading = gsr.SensorReading
+	if s.EventType.IsThreshold() {
+		s.Status = gsr.ThresholdStatus()
+	} else {
+		s.States = gsr.DiscreteStates(s.SensorType, s.EventType)
+	}
+	return gsr, nil
+}
+
+func (s *Sensor) readFull(c *Client, r *SDRFullSensor) error {
+	gsr, err := s.read(c)
+	if err != nil || gsr == nil {
+		return err
+	}
+	if s.Analog {
+		if s.Value, err = r.ConvertSensorReadingFactors(c, gsr.SensorReading); err != nil {
+			return err
+		}
+	}
+
+	// Threshold access support (0x01: readable, 0x02: readable and settable)
+	if !r.IsThresholdBaseSensor() || !s.Analog ||
+		(r.SensorCapabilities.Threshold != 0x01 && r.SensorCapabilities.Threshold != 0x02) {
+		return nil
+	}
+	gst := &GetSensorThresholdsCommand{RsLUN: s.OwnerLUN, SensorNumber: s.Number}
+	if err := sensorExecute(c, s.OwnerID, s.ChannelNumber, gst); err != nil {
+		if _, ok := err.(*CommandError); ok {
+			return nil
+		}
+		return err
+	}
+	s.Thresholds = make(map[ThresholdStatus]float64)
+	for _, t := range sensorThresholds {
+		if raw, ok := gst.Threshold(t.mask); ok {
+			if s.Thresholds[t.status], err = r.ConvertSensorReadingFactors(c, raw); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Sensor) setEventOnly(r *SDREventOnlySensor) {
+	s.Number = r.SensorNumber
+	s.OwnerID = r.OwnerID
+	s.OwnerLUN = r.OwnerLUN
+	s.ChannelNumber = r.ChannelNumber
+	s.EntityID = r.Entity.ID
+	s.EntityInstance = r.Entity.Instance
+	s.SensorType = r.SensorType
+	s.EventType = EventType(r.EventReadingType)
+}
+
+// readRecord Gets the reading of the sensor, event-only sensors have no reading
+func (s *Sensor) readRecord(c *Client) error {
+	switch r := s.Record.(type) {
+	case *SDRFullSensor:
+		return s.readFull(c, r)
+	case *SDRCompactSensor:
+		_, err := s.read(c)
+		return err
+	}
+	return nil
+}
+
+// newSensors Returns sensors of the record without reading, one for each sensor number of a shared record
+func newSensors(r SDR) []*Sensor {
+	// Share count 0 is the same as 1
+	count := func(n uint8) int {
+		if n == 0 {
+			return 1
+		}
+		return int(n)
+	}
+
+	switch record := r.(type) {
+	case *SDRFullSensor:
+		s := &Sensor{Record: r, Name: record.SensorID()}
+		s.setCommon(&record.SDRCommonSensor)
+		if s.Analog = record.IsAnalogReading(); s.Analog {
+			s.Unit = record.UnitString()
+		}
+		return []*Sensor{s}
+	case *SDRCompactSensor:
+		sensors := make([]*Sensor, 0, count(record.Share.Count))
+		for i := 0; i < cap(sensors); i++ {
+			s := &Sensor{Record: r, Name: record.SharedSensorID(i)}
+			s.setCommon(&record.SDRCommonSensor)
+			s.Number += uint8(i)
+			if record.Share.EntityInstance == 1 {
+				s.EntityInstance += uint8(i)
+			}
+			sensors = append(sensors, s)
+		}
+		return sensors
+	case *SDREventOnlySensor:
+		sensors := make([]*Sensor, 0, count(record.Share.Count))
+		for i := 0; i < cap(sensors); i++ {
+			s := &Sensor{Record: r, Name: record.SharedSensorID(i)}
+			s.setEventOnly(record)
+			s.Number += uint8(i)
+			if record.Share.EntityInstance == 1 {
+				s.EntityInstance += uint8(i)
+			}
+			sensors = append(sensors, s)
+		}
+		return sensors
+	default:
+		return []*Sensor{{Record: r}}
+	}
+}
+
+// SensorExecute Executes a sensor command (e.g. Get Sensor Reading) at the controller owning the sensor,
+// bridged through the BMC if the owner is another controller. The LUN is set by the command (OwnerLUN).
+func SensorExecute(c *Client, r *SDRCommonSensor, cmd Command) error {
+	return sensorExecute(c, r.OwnerID, r.ChannelNumber, cmd)
+}
+
+func sensorExecute(c *Client, ownerID, channel uint8, cmd Command) error {
+	if ownerID&0x01 != 0 {
+		// Owned by system software, only the BMC can answer
+		return c.Execute(cmd)
+	}
+	return c.Execute(NewBridgedCommand(channel, ownerID, cmd))
+}
+
+// SensorRead Returns the current reading of the sensor record,
+// the first sensor of a shared record (See SensorReadRecord).
+func SensorRead(c *Client, r SDR) (*Sensor, error) {
+	s := newSensors(r)[0]
+	if err := s.readRecord(c); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SensorReadRecord Returns the current readings of all sensors of the record,
+// a shared compact or event-only record has a reading for each sensor number.
+func SensorReadRecord(c *Client, r SDR) ([]*Sensor, error) {
+	sensors := newSensors(r)
+	for _, s := range sensors {
+		if err := s.readRecord(c); err != nil {
+			return nil, err
+		}
+	}
+	return sensors, nil
+}
+
+// SensorGetReadings Returns readings of full and compact sensors and event-only sensors (without reading).
+// filter selects records to be read, nil selects all.
+func SensorGetReadings(c *Client, filter func(r SDR) bool) ([]*Sensor, error) {
+	return SensorGetReadingsContext(context.Background(), c, filter)
+}
+
+// SensorGetReadingsContext Same as SensorGetReadings, but stops reading SDR repository and sensors
+// when ctx is done and returns its error. A command in progress is not interrupted.
+func SensorGetReadingsContext(ctx context.Context, c *Client, filter func(r SDR) bool) ([]*Sensor, error) {
+	records, err := sdrGetRecordsRepo(ctx, c, func(id uint16, t SDRType) bool {
+		return t == SDRTypeFullSensor || t == SDRTypeCompactSensor || t == SDRTypeEventOnlySensor
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sensors := make([]*Sensor, 0, len(records))
+	for _, r := range records {
+		if filter != nil && !filter(r) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		s, err := SensorReadRecord(c, r)
+		if err != nil {
+			return nil, err
+		}
+		sensors = append(sensors, s...)
+	}
+	return sensors, nil
+}
@@ -0,0 +1,45 @@
+package ipmigo
+
+import (
+	"testing"
+)
+
+func TestNewSensorsShared(t *testing.T) {
+	r := &SDRCompactSensor{SDRCommonSensor: SDRCommonSensor{header: &sdrHeader{RecordType: SDRTypeCompactSensor}}}
+	r.SensorNumber = 0x10
+	r.Entity.Instance = 1
+	r.Share.Count = 3
+	r.Share.ModifierOffset = 1
+	r.Share.EntityInstance = 1
+	r.IDType = 0x03
+	r.IDString = []byte("CPU")
+
+	sensors := newSensors(r)
+	if len(sensors) != 3 {
+		t.Fatalf("len(sensors) = %d, want 3", len(sensors))
+	}
+	for i, s := range sensors {
+		if want := uint8(0x10 + i); s.Number != want {
+			t.Errorf("sensors[%d].Number = 0x%02x, want 0x%02x", i, s.Number, want)
+		}
+		if want := uint8(1 + i); s.EntityInstance != want {
+			t.Errorf("sensors[%d].EntityInstance = %d, want %d", i, s.EntityInstance, want)
+		}
+		if s.Record != r {
+			t.Errorf("sensors[%d].Record = %v, want %v", i, s.Record, r)
+		}
+	}
+	for i, want := range []string{"CPU1", "CPU2", "CPU3"} {
+		if sensors[i].Name != want {
+			t.Errorf("sensors[%d].Name = %q, want %q", i, sensors[i].Name, want)
+		}
+	}
+
+	r.Share.ModifierType = 0x01
+	r.Share.ModifierOffset = 25
+	for i, want := range []string{"CPUZ", "CPUAA", "CPUAB"} {
+		if name := r.SharedSensorID(i); name != want {
+			t.Errorf("SharedSensorID(%d) = %q, want %q", i, name, want)
+		}
+	}
+}
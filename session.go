@@ -96,5 +96,6 @@ type session interface {
 	Ping() error
 	Open() error
 	Close() error
+	Abandon() error
 	Execute(Command) error
 }
@@ -0,0 +1,111 @@
+package ipmigo
+
+import (
+	"net"
+)
+
+// ActiveSession Active session on BMC
+type ActiveSession struct {
+	Handle         uint8
+	UserID         uint8
+	UserName       string // Empty if the user name can not be obtained
+	PrivilegeLevel PrivilegeLevel
+	ChannelType    uint8 // (0x00: IPMI v1.5, 0x01: IPMI v2.0)
+	ChannelNumber  uint8
+	ConsoleIP      net.IP // nil if channel is not a LAN channel
+	ConsoleMAC     net.HardwareAddr
+	ConsolePort    uint16
+	Current        bool // `true` if it is the session used by the client
+}
+
+// SessionGetActive Returns all active sessions with the slot count of BMC.
+func SessionGetActive(c *Client) (sessions []*ActiveSession, slots uint8, err error) {
+	current := &GetSessionInfoCommand{SessionIndex: 0x00}
+	if err = c.Execute(current); err != nil {
+		return
+	}
+	slots = current.SessionSlotCount
+
+	names := make(map[uint8]string)
+	for n := uint8(1); n <= current.SessionSlotCount; n++ {
+		gsi := &GetSessionInfoCommand{SessionIndex: n}
+		if err = c.Execute(gsi); err != nil {
+			if _, ok := err.(*CommandError); ok && len(sessions) > 0 {
+				// Index exceeds the number of active sessions
+				err = nil
+				break
+			}
+			return
+		}
+		if !gsi.IsActive() {
+			break
+		}
+
+		name, ok := names[gsi.UserID]
+		if !ok {
+			gun := &GetUserNameCommand{UserID: gsi.UserID}
+			if e := c.Execute(gun); e == nil {
+				name = gun.UserName
+			}
+			names[gsi.UserID] = name
+		}
+
+		sessions = append(sessions, &ActiveSession{
+			Handle:         gsi.SessionHandle,
+			UserID:         gsi.UserID,
+			UserName:       name,
+			PrivilegeLevel: gsi.PrivilegeLevel,
+			ChannelType:    gsi.ChannelType,
+			ChannelNumber:  gsi.ChannelNumber,
+			ConsoleIP:      gsi.ConsoleIP,
+			ConsoleMAC:     gsi.ConsoleMAC,
+			ConsolePort:    gsi.ConsolePort,
+			Current:        gsi.SessionHandle == current.SessionHandle,
+		})
+		if len(sessions) >= int(gsi.ActiveSessionCount) {
+			break
+		}
+	}
+
+	return
+}
+
+// SessionClose Closes another session by its handle, the session used by the client can not be closed.
+func SessionClose(c *Client, handle uint8) error {
+	current := &GetSessionInfoCommand{SessionIndex: 0x00}
+	if err := c.Execute(current); err != nil {
+		return err
+	}
+	if handle == current.SessionHandle {
+		return &ArgumentError{
+			Value:   handle,
+			Message: "Unable to close the current session, use Client.Close",
+		}
+	}
+
+	return c.Execute(&CloseSessionCommand{SessionHandle: handle})
+}
+
+// SessionCloseByID Closes another session by its session ID, the session used by the client can not be closed.
+func SessionCloseByID(c *Client, id uint32) error {
+	if id == 0 {
+		return &ArgumentError{Value: id, Message: "Invalid session ID"}
+	}
+
+	current := &GetSessionInfoCommand{SessionIndex: 0x00}
+	if err := c.Execute(current); err != nil {
+		return err
+	}
+	target := &GetSessionInfoCommand{SessionIndex: 0xff, SessionID: id}
+	if err := c.Execute(target); err != nil {
+		return err
+	}
+	if target.SessionHandle == current.SessionHandle {
+		return &ArgumentError{
+			Value:   id,
+			Message: "Unable to close the current session, use Client.Close",
+		}
+	}
+
+	return c.Execute(&CloseSessionCommand{SessionID: id})
+}
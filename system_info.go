@@ -0,0 +1,160 @@
+package ipmigo
+
+import (
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	systemInfoBlockSize     = 16
+	systemInfoStringMaxSize = 255
+)
+
+// SystemInfoEncoding String encoding of System Info Parameters (Table 22-16a)
+type SystemInfoEncoding uint8
+
+const (
+	SystemInfoEncodingASCII   SystemInfoEncoding = 0x00 // ASCII+Latin1
+	SystemInfoEncodingUTF8    SystemInfoEncoding = 0x01
+	SystemInfoEncodingUnicode SystemInfoEncoding = 0x02
+)
+
+func (e SystemInfoEncoding) decode(b []byte) string {
+	switch e {
+	case SystemInfoEncodingUTF8:
+		return string(b)
+	case SystemInfoEncodingUnicode:
+		u := make([]uint16, len(b)/2)
+		for i := range u {
+			u[i] = uint16(b[2*i]) | uint16(b[2*i+1])<<8
+		}
+		return string(utf16.Decode(u))
+	default:
+		// Latin1 code points are equal to Unicode code points
+		r := make([]rune, len(b))
+		for i, c := range b {
+			r[i] = rune(c)
+		}
+		return string(r)
+	}
+}
+
+func systemInfoGetBlock(c *Client, param SystemInfoParameter, set uint8) ([]byte, error) {
+	gsi := &GetSystemInfoParametersCommand{
+		ParameterSelector: param,
+		SetSelector:       set,
+	}
+	if err := c.Execute(gsi); err != nil {
+		return nil, err
+	}
+	// The first byte of a block is its set selector
+	if l := len(gsi.Data); l < 2 {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid %s block %d size : %d", param, set, l),
+			Detail:  gsi.String(),
+		}
+	}
+	if gsi.Data[0] != set {
+		return nil, &MessageError{
+			Message: fmt.Sprintf("Invalid %s block set selector : %d/%d", param, gsi.Data[0], set),
+			Detail:  gsi.String(),
+		}
+	}
+	return gsi.Data[1:], nil
+}
+
+// SystemInfoGetString Returns string value of System Info Parameter (parameters 1-7) reassembled from all blocks.
+func SystemInfoGetString(c *Client, param SystemInfoParameter) (string, error) {
+	block, err := systemInfoGetBlock(c, param, 0)
+	if err != nil {
+		return "", err
+	}
+	if len(block) < 2 {
+		return "", &MessageError{Message: fmt.Sprintf("Invalid %s first block size : %d", param, len(block))}
+	}
+
+	encoding := SystemInfoEncoding(block[0] & 0x0f)
+	length := int(block[1])
+	data := make([]byte, 0, length)
+	data = append(data, block[2:]...)
+
+	for set := uint8(1); len(data) < length; set++ {
+		if block, err = systemInfoGetBlock(c, param, set); err != nil {
+			return "", err
+		}
+		data = append(data, block...)
+	}
+	if len(data) > length {
+		data = data[:length]
+	}
+
+	return encoding.decode(data), nil
+}
+
+// SystemInfoSetString Sets string value of System Info Parameter (parameters 1-7) split into blocks.
+// ASCII strings are stored as ASCII+Latin1, others as UTF-8.
+func SystemInfoSetString(c *Client, param SystemInfoParameter, value string) error {
+	if !utf8.ValidString(value) {
+		return &ArgumentError{Value: value, Message: "Invalid UTF-8 string"}
+	}
+	if len(value) > systemInfoStringMaxSize {
+		return &ArgumentError{Value: value, Message: "String is too long"}
+	}
+
+	encoding := SystemInfoEncodingASCII
+	for i := 0; i < len(value); i++ {
+		if value[i] >= utf8.RuneSelf {
+			encoding = SystemInfoEncodingUTF8
+			break
+		}
+	}
+
+	// Set Complete is only sent if Set In Progress is supported by BMC
+	inProgress := false
+	sip := &SetSystemInfoParametersCommand{
+		ParameterSelector: SystemInfoParamSetInProgress,
+		Data:              []byte{0x01},
+	}
+	if err := c.Execute(sip); err == nil {
+		inProgress = true
+	} else if _, ok := err.(*CommandError); !ok {
+		return err
+	}
+
+	err := systemInfoSetBlocks(c, param, encoding, []byte(value))
+
+	if inProgress {
+		sip.Data = []byte{0x00}
+		if e := c.Execute(sip); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+func systemInfoSetBlocks(c *Client, param SystemInfoParameter, encoding SystemInfoEncoding, data []byte) error {
+	block := make([]byte, 0, systemInfoBlockSize)
+	block = append(block, uint8(encoding), uint8(len(data)))
+
+	for set, n := uint8(0), 0; set == 0 || n < len(data); set++ {
+		size := systemInfoBlockSize - len(block)
+		if r := len(data) - n; size > r {
+			size = r
+		}
+		block = append(block, data[n:n+size]...)
+		n += size
+
+		// Blocks are always 16 bytes, padded with zeros
+		padded := make([]byte, systemInfoBlockSize+1)
+		padded[0] = set
+		copy(padded[1:], block)
+
+		ssi := &SetSystemInfoParametersCommand{ParameterSelector: param, Data: padded}
+		if err := c.Execute(ssi); err != nil {
+			return err
+		}
+		block = block[:0]
+	}
+	return nil
+}